@@ -0,0 +1,106 @@
+package frost
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// Authenticate produces the Schnorr signature σ = (R, z) of the message under
+// the signer's secret key share sk_i. The signature is verifiable with
+// VerifyAuthentication by anyone knowing the verification share
+// PK_i = G * sk_i, so it binds the message to the signer. This is not
+// a [FROST] signature; it lets protocols built on top of [FROST] prove which
+// signer sent a message, for example, an invalid signature share.
+//
+// The signature is computed as k ← Z_q, R = G * k, c = HAuth(i, PK_i, R, m),
+// z = k + sk_i * c.
+func (s *Signer) Authenticate(message []byte) (*Signature, error) {
+	curve := s.ciphersuite.Curve()
+	order := curve.Order()
+
+	k, err := randomScalar(curve)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate authentication nonce: [%v]", err)
+	}
+	R := curve.EcBaseMul(k)
+
+	c := authenticationChallenge(
+		s.ciphersuite,
+		s.signerIndex,
+		curve.EcBaseMul(s.secretKeyShare),
+		R,
+		message,
+	)
+	z := new(big.Int).Mul(s.secretKeyShare, c)
+	z.Add(z, k)
+	z.Mod(z, order)
+
+	return &Signature{R: R, Z: z}, nil
+}
+
+// VerifyAuthentication verifies the signature produced with Authenticate by
+// the signer with the given identifier and verification share. The function
+// returns nil if the signature is valid and an error explaining why it is not
+// otherwise.
+func VerifyAuthentication(
+	ciphersuite Ciphersuite,
+	signerIndex uint64,
+	verificationShare *Point,
+	message []byte,
+	signature *Signature,
+) error {
+	curve := ciphersuite.Curve()
+
+	if verificationShare == nil || verificationShare.X == nil ||
+		verificationShare.Y == nil || !curve.IsPointOnCurve(verificationShare) {
+		return fmt.Errorf(
+			"verification share of signer [%d] is not a valid point on the curve",
+			signerIndex,
+		)
+	}
+
+	if signature == nil || signature.R == nil || signature.R.X == nil ||
+		signature.R.Y == nil || !curve.IsPointOnCurve(signature.R) ||
+		signature.Z == nil || signature.Z.Sign() < 0 ||
+		signature.Z.Cmp(curve.Order()) >= 0 {
+		return fmt.Errorf("authentication of signer [%d] is malformed", signerIndex)
+	}
+
+	// R ?= G * z - PK_i * c
+	c := authenticationChallenge(
+		ciphersuite,
+		signerIndex,
+		verificationShare,
+		signature.R,
+		message,
+	)
+	expected := curve.EcSub(
+		curve.EcBaseMul(signature.Z),
+		curve.EcMul(verificationShare, c),
+	)
+	if !isPointEqual(signature.R, expected) {
+		return fmt.Errorf("authentication of signer [%d] is invalid", signerIndex)
+	}
+
+	return nil
+}
+
+// authenticationChallenge computes c = HAuth(i, PK_i, R, m), the challenge
+// of the signer's authentication of the message.
+func authenticationChallenge(
+	ciphersuite Ciphersuite,
+	signerIndex uint64,
+	verificationShare *Point,
+	R *Point,
+	message []byte,
+) *big.Int {
+	curve := ciphersuite.Curve()
+
+	return ciphersuite.HAuth(
+		binary.BigEndian.AppendUint64(nil, signerIndex),
+		curve.SerializePoint(verificationShare),
+		curve.SerializePoint(R),
+		message,
+	)
+}
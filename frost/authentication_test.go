@@ -0,0 +1,123 @@
+package frost
+
+import (
+	"math/big"
+	"testing"
+
+	"threshold.network/roast/internal/testutils"
+)
+
+func TestAuthenticate(t *testing.T) {
+	message := []byte("Not all those who wander are lost")
+
+	signers := createSigners(t)
+	shares := verificationShares(signers)
+
+	signature, err := signers[2].Authenticate(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = VerifyAuthentication(ciphersuite, 3, shares[3], message, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyAuthentication_Failures(t *testing.T) {
+	curve := ciphersuite.Curve()
+	message := []byte("Not all those who wander are lost")
+
+	signers := createSigners(t)
+	shares := verificationShares(signers)
+
+	signature, err := signers[2].Authenticate(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		signerIndex       uint64
+		verificationShare *Point
+		message           []byte
+		signature         *Signature
+		expectedErr       string
+	}{
+		"another message": {
+			signerIndex:       3,
+			verificationShare: shares[3],
+			message:           []byte("All that is gold does not glitter"),
+			signature:         signature,
+			expectedErr:       "authentication of signer [3] is invalid",
+		},
+		"another signer": {
+			signerIndex:       4,
+			verificationShare: shares[4],
+			message:           message,
+			signature:         signature,
+			expectedErr:       "authentication of signer [4] is invalid",
+		},
+		"another signer identifier": {
+			signerIndex:       4,
+			verificationShare: shares[3],
+			message:           message,
+			signature:         signature,
+			expectedErr:       "authentication of signer [4] is invalid",
+		},
+		"nil signature": {
+			signerIndex:       3,
+			verificationShare: shares[3],
+			message:           message,
+			signature:         nil,
+			expectedErr:       "authentication of signer [3] is malformed",
+		},
+		"R not on the curve": {
+			signerIndex:       3,
+			verificationShare: shares[3],
+			message:           message,
+			signature: &Signature{
+				R: &Point{X: big.NewInt(1), Y: big.NewInt(2)},
+				Z: signature.Z,
+			},
+			expectedErr: "authentication of signer [3] is malformed",
+		},
+		"z not lower than the group order": {
+			signerIndex:       3,
+			verificationShare: shares[3],
+			message:           message,
+			signature: &Signature{
+				R: signature.R,
+				Z: new(big.Int).Add(signature.Z, curve.Order()),
+			},
+			expectedErr: "authentication of signer [3] is malformed",
+		},
+		"nil verification share": {
+			signerIndex:       3,
+			verificationShare: nil,
+			message:           message,
+			signature:         signature,
+			expectedErr:       "verification share of signer [3] is not a valid point on the curve",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := VerifyAuthentication(
+				ciphersuite,
+				test.signerIndex,
+				test.verificationShare,
+				test.message,
+				test.signature,
+			)
+			if err == nil {
+				t.Fatal("expected non-nil error")
+			}
+			testutils.AssertStringsEqual(
+				t,
+				"verification error",
+				test.expectedErr,
+				err.Error(),
+			)
+		})
+	}
+}
@@ -208,6 +208,22 @@ func (b *Bip340Ciphersuite) H5(m []byte) []byte {
 	return hash[:]
 }
 
+// HDKG is the implementation of HDKG(m) function used in the distributed key
+// generation.
+func (b *Bip340Ciphersuite) HDKG(m []byte, ms ...[]byte) *big.Int {
+	// The tag is DST = contextString || "dkg", following the convention of
+	// H1, H3, H4, and H5.
+	dst := concat(b.contextString(), []byte("dkg"))
+	return b.hashToScalar(dst, concat(m, ms...))
+}
+
+// HAuth is the implementation of HAuth(m) function used in the signer's
+// authentication of a message.
+func (b *Bip340Ciphersuite) HAuth(m []byte, ms ...[]byte) *big.Int {
+	dst := concat(b.contextString(), []byte("auth"))
+	return b.hashToScalar(dst, concat(m, ms...))
+}
+
 // contextString is a contextString as required by [FROST] to be used in tagged
 // hashes. The value is specific to [BIP-340] ciphersuite.
 func (b *Bip340Ciphersuite) contextString() []byte {
@@ -264,6 +280,57 @@ func (b *Bip340Ciphersuite) EncodePoint(point *Point) []byte {
 	return xbs
 }
 
+// TaprootTweak computes the [BIP-341] tweak of the internal public key for
+// the given script tree Merkle root:
+//
+//	t = int(hash_TapTweak(bytes(P) || merkle_root))
+//
+// The Merkle root must be empty for the key-path-only output with no script
+// tree, as recommended by [BIP-341], or have 32 bytes otherwise. The tweak
+// should be applied on all signers and the coordinator with ApplyXOnlyTweak
+// to produce signatures valid under the Taproot output key.
+//
+// [BIP-341]: https://github.com/bitcoin/bips/blob/master/bip-0341.mediawiki
+func (b *Bip340Ciphersuite) TaprootTweak(
+	internalKey *Point,
+	merkleRoot []byte,
+) (*big.Int, error) {
+	if len(merkleRoot) != 0 && len(merkleRoot) != 32 {
+		return nil, fmt.Errorf(
+			"merkle root must be empty or have 32 bytes; has [%d] bytes",
+			len(merkleRoot),
+		)
+	}
+
+	// From [BIP-341]:
+	//
+	// def taproot_tweak_pubkey(pubkey, h):
+	//     t = int_from_bytes(tagged_hash("TapTweak", pubkey + h))
+	//     if t >= SECP256K1_ORDER:
+	//         raise ValueError
+	//
+	// bytes(P) is the x-only encoding of the internal public key.
+	hashed := b.hash([]byte("TapTweak"), concat(b.EncodePoint(internalKey), merkleRoot))
+	tweak := os2ip(hashed[:])
+	if tweak.Cmp(b.curve.N) >= 0 {
+		return nil, fmt.Errorf("tweak exceeds the group order")
+	}
+
+	return tweak, nil
+}
+
+// RequiresEvenY returns true as [BIP-340] implicitly assumes the even Y
+// coordinate of both the public key and the R point of the signature:
+//
+// "Implicit Y coordinates: In order to support efficient verification and
+// batch verification, the Y coordinate of P and of R cannot be ambiguous
+// (every valid X coordinate has two possible Y coordinates). (...) we choose
+// the option that [is] the most efficient: implicitly choosing the Y
+// coordinate that is even."
+func (b *Bip340Ciphersuite) RequiresEvenY() bool {
+	return true
+}
+
 // VerifySignature verifies the provided [BIP-340] signature for the message
 // against the group public key. The function returns true and nil error when
 // the signature is valid. The function returns false and an error when the
@@ -321,21 +388,70 @@ func (b *Bip340Ciphersuite) VerifySignature(
 		return false, fmt.Errorf("liftX failed: [%v]", err)
 	}
 
+	return b.verify(
+		P,
+		signature.R.X, // int(sig[0:32])
+		signature.Z,   // int(sig[32:64])
+		message,
+	)
+}
+
+// VerifySignatureBytes verifies the [BIP-340] signature for the message
+// against the public key, both given in the [BIP-340] format: the 64-byte
+// signature and the 32-byte x-only public key. The function returns true and
+// nil error when the signature is valid. The function returns false and an
+// error when the signature is invalid.
+//
+// Unlike VerifySignature, this function implements Verify(pk, m, sig) from
+// [BIP-340] exactly, without any conversions of the input, so it can verify
+// signatures exchanged with the rest of the Bitcoin stack.
+func (b *Bip340Ciphersuite) VerifySignatureBytes(
+	signature []byte,
+	publicKey []byte,
+	message []byte,
+) (bool, error) {
+	// Let P = lift_x(int(pk)); fail if that fails.
+	P, err := b.ParsePublicKey(publicKey)
+	if err != nil {
+		return false, err
+	}
+
+	if len(signature) != 64 {
+		return false, fmt.Errorf(
+			"signature must have 64 bytes; has [%d] bytes",
+			len(signature),
+		)
+	}
+
+	return b.verify(
+		P,
+		new(big.Int).SetBytes(signature[0:32]),  // int(sig[0:32])
+		new(big.Int).SetBytes(signature[32:64]), // int(sig[32:64])
+		message,
+	)
+}
+
+// verify implements Verify(pk, m, sig) function defined in [BIP-340] from the
+// moment the public key point P is lifted from the x-only public key.
+func (b *Bip340Ciphersuite) verify(
+	P *Point,
+	r *big.Int,
+	s *big.Int,
+	message []byte,
+) (bool, error) {
 	// Let r = int(sig[0:32]); fail if r ≥ p.
-	r := signature.R.X // int(sig[0:32])
 	if r.Cmp(b.curve.P) != -1 {
 		return false, fmt.Errorf("r >= P")
 	}
 
 	// Let s = int(sig[32:64]); fail if s ≥ n.
-	s := signature.Z // int(sig[32:64])
 	if s.Cmp(b.curve.N) != -1 {
 		return false, fmt.Errorf("s >= N")
 	}
 
 	// Let e = int(hashBIP0340/challenge(bytes(r) || bytes(P) || m)) mod n.
 	eHash := b.H2(
-		b.EncodePoint(signature.R),
+		r.FillBytes(make([]byte, 32)),
 		b.EncodePoint(P),
 		message)
 	e := new(big.Int).Mod(eHash, b.curve.N)
@@ -365,6 +481,79 @@ func (b *Bip340Ciphersuite) VerifySignature(
 	return true, nil
 }
 
+// SerializeSignature serializes the signature to the 64-byte format defined
+// in [BIP-340]: bytes(R) || bytes(z), where bytes(R) is the X coordinate of R.
+// The Y coordinate of R is not serialized. [BIP-340] implicitly assumes it is
+// even and the signature with an odd Y of R does not verify after parsing.
+// The signatures aggregated by the Coordinator always have an even Y of R.
+func (b *Bip340Ciphersuite) SerializeSignature(signature *Signature) ([]byte, error) {
+	if signature == nil || signature.R == nil || signature.R.X == nil ||
+		signature.Z == nil {
+		return nil, fmt.Errorf("signature is incomplete")
+	}
+	if signature.R.X.Sign() < 0 || signature.R.X.Cmp(b.curve.P) != -1 {
+		return nil, fmt.Errorf("r >= P")
+	}
+	if signature.Z.Sign() < 0 || signature.Z.Cmp(b.curve.N) != -1 {
+		return nil, fmt.Errorf("s >= N")
+	}
+
+	serialized := make([]byte, 64)
+	signature.R.X.FillBytes(serialized[0:32])
+	signature.Z.FillBytes(serialized[32:64])
+	return serialized, nil
+}
+
+// ParseSignature parses the 64-byte signature in the format defined in
+// [BIP-340]. The R point of the parsed signature is lifted from its X
+// coordinate with lift_x(x) so it always has an even Y coordinate. The
+// function returns an error if the signature does not have 64 bytes, if
+// r ≥ p, if s ≥ n, or if there is no point with the X coordinate r. Such
+// signatures never pass the [BIP-340] verification.
+func (b *Bip340Ciphersuite) ParseSignature(signature []byte) (*Signature, error) {
+	if len(signature) != 64 {
+		return nil, fmt.Errorf(
+			"signature must have 64 bytes; has [%d] bytes",
+			len(signature),
+		)
+	}
+
+	r := new(big.Int).SetBytes(signature[0:32])
+	if r.Cmp(b.curve.P) != -1 {
+		return nil, fmt.Errorf("r >= P")
+	}
+	s := new(big.Int).SetBytes(signature[32:64])
+	if s.Cmp(b.curve.N) != -1 {
+		return nil, fmt.Errorf("s >= N")
+	}
+
+	R, err := b.liftX(r)
+	if err != nil {
+		return nil, fmt.Errorf("liftX failed: [%v]", err)
+	}
+
+	return &Signature{R: R, Z: s}, nil
+}
+
+// ParsePublicKey parses the 32-byte x-only public key defined in [BIP-340]
+// and returns the point with an even Y coordinate, lift_x(int(pk)). The
+// x-only public key is serialized with EncodePoint.
+func (b *Bip340Ciphersuite) ParsePublicKey(publicKey []byte) (*Point, error) {
+	if len(publicKey) != 32 {
+		return nil, fmt.Errorf(
+			"public key must have 32 bytes; has [%d] bytes",
+			len(publicKey),
+		)
+	}
+
+	P, err := b.liftX(new(big.Int).SetBytes(publicKey))
+	if err != nil {
+		return nil, fmt.Errorf("liftX failed: [%v]", err)
+	}
+
+	return P, nil
+}
+
 // liftX function implements lift_x(x) function as defined in [BIP-340].
 func (b *Bip340Ciphersuite) liftX(x *big.Int) (*Point, error) {
 	// From [BIP-340] specification section:
@@ -709,3 +709,384 @@ func TestOs2Ip(t *testing.T) {
 		})
 	}
 }
+
+func TestBip340CiphersuiteTaprootTweak(t *testing.T) {
+	// Test vectors from [BIP-341] wallet test vectors, scriptPubKey section.
+	tests := map[string]struct {
+		internalKey      string
+		merkleRoot       string
+		expectedTweak    string
+		expectedTweakedX string
+	}{
+		"key path only": {
+			internalKey:      "d6889cb081036e0faefa3a35157ad71086b123b2b144b649798b494c300a961d",
+			merkleRoot:       "",
+			expectedTweak:    "b86e7be8f39bab32a6f2c0443abbc210f0edac0e2c53d501b36b64437d9c6c70",
+			expectedTweakedX: "53a1f6e454df1aa2776a2814a721372d6258050de330b3c6d10ee8f4e0dda343",
+		},
+		"script tree": {
+			internalKey:      "187791b6f712a8ea41c8ecdd0ee77fab3e85263b37e1ec18a3651926b3a6cf27",
+			merkleRoot:       "5b75adecf53548f3ec6ad7d78383bf84cc57b55a3127c72b9a2481752dd88b21",
+			expectedTweak:    "cbd8679ba636c1110ea247542cfbd964131a6be84f873f7f3b62a777528ed001",
+			expectedTweakedX: "147c9c57132f6e7ecddba9800bb0c4449251c92a1e60371ee77557b6620f3ea3",
+		},
+	}
+
+	ciphersuite := NewBip340Ciphersuite()
+	curve := ciphersuite.Curve()
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			x, _ := new(big.Int).SetString(test.internalKey, 16)
+			internalKey, err := ciphersuite.liftX(x)
+			if err != nil {
+				t.Fatal(err)
+			}
+			merkleRoot, err := hex.DecodeString(test.merkleRoot)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tweak, err := ciphersuite.TaprootTweak(internalKey, merkleRoot)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutils.AssertStringsEqual(
+				t,
+				"tweak",
+				test.expectedTweak,
+				hex.EncodeToString(tweak.FillBytes(make([]byte, 32))),
+			)
+
+			tweaked := curve.EcAdd(internalKey, curve.EcBaseMul(tweak))
+			testutils.AssertStringsEqual(
+				t,
+				"tweaked public key",
+				test.expectedTweakedX,
+				hex.EncodeToString(ciphersuite.EncodePoint(tweaked)),
+			)
+		})
+	}
+}
+
+func TestBip340CiphersuiteTaprootTweak_InvalidMerkleRoot(t *testing.T) {
+	ciphersuite := NewBip340Ciphersuite()
+	internalKey := ciphersuite.Curve().EcBaseMul(big.NewInt(10))
+
+	_, err := ciphersuite.TaprootTweak(internalKey, make([]byte, 31))
+	testutils.AssertStringsEqual(
+		t,
+		"taproot tweak error",
+		"merkle root must be empty or have 32 bytes; has [31] bytes",
+		err.Error(),
+	)
+}
+
+func TestBip340CiphersuiteVerifySignatureBytes(t *testing.T) {
+	// Official [BIP-340] test vectors: https://github.com/bitcoin/bips/blob/master/bip-0340/test-vectors.csv
+	tests := map[string]struct {
+		signature   string
+		publicKey   string
+		message     string
+		isValid     bool
+		expectedErr string
+	}{
+		"vector 0": {
+			signature: "E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+			publicKey: "F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+			message:   "0000000000000000000000000000000000000000000000000000000000000000",
+			isValid:   true,
+		},
+		"vector 4": {
+			signature: "00000000000000000000003B78CE563F89A0ED9414F5AA28AD0D96D6795F9C6376AFB1548AF603B3EB45C9F8207DEE1060CB71C04E80F593060B07D28308D7F4",
+			publicKey: "D69C3509BB99E412E68B0FE8544E72837DFA30746D8BE2AA65975F29D22DC7B9",
+			message:   "4DF3C3F68FCC83B27E9D42C90431A72499F17875C81A599B566C9889B9696703",
+			isValid:   true,
+		},
+		"vector 15, empty message": {
+			signature: "71535DB165ECD9FBBC046E5FFAEA61186BB6AD436732FCCC25291A55895464CF6069CE26BF03466228F19A3A62DB8A649F2D560FAC652827D1AF0574E427AB63",
+			publicKey: "778CAA53B4393AC467774D09497A87224BF9FAB6F6E68B23086497324D6FD117",
+			message:   "",
+			isValid:   true,
+		},
+		"vector 5, public key not on the curve": {
+			signature:   "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E17776969E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+			publicKey:   "EEFDEA4CDB677750A420FEE807EACF21EB9898AE79B9768766E4FAA04A2D4A34",
+			message:     "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			expectedErr: "liftX failed: [no curve point matching x]",
+		},
+		"vector 6, odd Y of R": {
+			signature:   "FFF97BD5755EEEA420453A14355235D382F6472F8568A18B2F057A14602975563CC27944640AC607CD107AE10923D9EF7A73C643E166BE5EBEAFA34B1AC553E2",
+			publicKey:   "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			message:     "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			expectedErr: "R.y is not even",
+		},
+		"vector 11, r not on the curve": {
+			signature:   "4A298DACAE57395A15D0795DDBFD1DCB564DA82B0F269BC70A74F8220429BA1D69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+			publicKey:   "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			message:     "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			expectedErr: "R.x != r",
+		},
+		"vector 12, r equal to the field size": {
+			signature:   "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+			publicKey:   "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			message:     "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			expectedErr: "r >= P",
+		},
+		"vector 13, s equal to the curve order": {
+			signature:   "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141",
+			publicKey:   "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			message:     "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			expectedErr: "s >= N",
+		},
+		"vector 14, public key exceeding the field size": {
+			signature:   "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E17776969E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+			publicKey:   "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC30",
+			message:     "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			expectedErr: "liftX failed: [value of x exceeds field size]",
+		},
+		"signature too short": {
+			signature:   "E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D3105",
+			publicKey:   "F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+			message:     "0000000000000000000000000000000000000000000000000000000000000000",
+			expectedErr: "signature must have 64 bytes; has [62] bytes",
+		},
+		"compressed public key": {
+			signature:   "E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+			publicKey:   "02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+			message:     "0000000000000000000000000000000000000000000000000000000000000000",
+			expectedErr: "public key must have 32 bytes; has [33] bytes",
+		},
+	}
+
+	ciphersuite := NewBip340Ciphersuite()
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			signature, err := hex.DecodeString(test.signature)
+			if err != nil {
+				t.Fatal(err)
+			}
+			publicKey, err := hex.DecodeString(test.publicKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			message, err := hex.DecodeString(test.message)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			valid, err := ciphersuite.VerifySignatureBytes(signature, publicKey, message)
+			testutils.AssertBoolsEqual(t, "signature validity", test.isValid, valid)
+			if test.isValid {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected not-nil error")
+			}
+			testutils.AssertStringsEqual(
+				t,
+				"signature verification error message",
+				test.expectedErr,
+				err.Error(),
+			)
+		})
+	}
+}
+
+func TestBip340CiphersuiteParseSerializeSignature(t *testing.T) {
+	ciphersuite := NewBip340Ciphersuite()
+
+	// [BIP-340] test vector 1.
+	serialized := mustDecodeHex(
+		t,
+		"6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE3341"+
+			"8906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+	)
+	publicKey, err := ciphersuite.ParsePublicKey(mustDecodeHex(
+		t,
+		"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := mustDecodeHex(
+		t,
+		"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+	)
+
+	signature, err := ciphersuite.ParseSignature(serialized)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "even Y of R", true, signature.R.Y.Bit(0) == 0)
+
+	valid, err := ciphersuite.VerifySignature(signature, publicKey, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "signature validity", true, valid)
+
+	reserialized, err := ciphersuite.SerializeSignature(signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBytesEqual(t, serialized, reserialized)
+}
+
+func TestBip340CiphersuiteParseSignature_Failures(t *testing.T) {
+	tests := map[string]struct {
+		signature   string
+		expectedErr string
+	}{
+		"too short": {
+			signature:   "6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE3341",
+			expectedErr: "signature must have 64 bytes; has [32] bytes",
+		},
+		"r equal to the field size": {
+			signature:   "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+			expectedErr: "r >= P",
+		},
+		"s equal to the curve order": {
+			signature:   "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141",
+			expectedErr: "s >= N",
+		},
+		"r not on the curve": {
+			signature:   "4A298DACAE57395A15D0795DDBFD1DCB564DA82B0F269BC70A74F8220429BA1D69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+			expectedErr: "liftX failed: [no curve point matching x]",
+		},
+	}
+
+	ciphersuite := NewBip340Ciphersuite()
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, err := ciphersuite.ParseSignature(mustDecodeHex(t, test.signature))
+			if err == nil {
+				t.Fatal("expected not-nil error")
+			}
+			testutils.AssertStringsEqual(
+				t,
+				"parse signature error",
+				test.expectedErr,
+				err.Error(),
+			)
+		})
+	}
+}
+
+func TestBip340CiphersuiteSerializeSignature_Failures(t *testing.T) {
+	ciphersuite := NewBip340Ciphersuite()
+	R := ciphersuite.Curve().EcBaseMul(big.NewInt(3))
+
+	tests := map[string]struct {
+		signature   *Signature
+		expectedErr string
+	}{
+		"nil signature": {
+			signature:   nil,
+			expectedErr: "signature is incomplete",
+		},
+		"nil z": {
+			signature:   &Signature{R: R},
+			expectedErr: "signature is incomplete",
+		},
+		"z equal to the curve order": {
+			signature:   &Signature{R: R, Z: ciphersuite.curve.N},
+			expectedErr: "s >= N",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, err := ciphersuite.SerializeSignature(test.signature)
+			if err == nil {
+				t.Fatal("expected not-nil error")
+			}
+			testutils.AssertStringsEqual(
+				t,
+				"serialize signature error",
+				test.expectedErr,
+				err.Error(),
+			)
+		})
+	}
+}
+
+func TestBip340CiphersuiteParsePublicKey(t *testing.T) {
+	ciphersuite := NewBip340Ciphersuite()
+	curve := ciphersuite.Curve()
+
+	// A point with an odd Y coordinate; parsing its x-only encoding returns
+	// the negated point with an even Y coordinate.
+	point := curve.EcBaseMul(big.NewInt(3))
+	if point.Y.Bit(0) == 0 {
+		point = curve.EcSub(curve.Identity(), point)
+	}
+
+	parsed, err := ciphersuite.ParsePublicKey(ciphersuite.EncodePoint(point))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBigIntsEqual(t, "X coordinate", point.X, parsed.X)
+	testutils.AssertBigIntsEqual(
+		t,
+		"Y coordinate",
+		new(big.Int).Sub(ciphersuite.curve.P, point.Y),
+		parsed.Y,
+	)
+}
+
+func TestFrostRoundtrip_Bip340Bytes(t *testing.T) {
+	message := []byte("For even the very wise cannot see all ends")
+
+	for _, oddY := range []bool{false, true} {
+		t.Run(fmt.Sprintf("odd Y of the public key: %v", oddY), func(t *testing.T) {
+			signers := createSignersForKey(t, generateSecretKey(t, oddY))[:threshold]
+			publicKey := signers[0].publicKey
+
+			coordinator := NewCoordinator(
+				ciphersuite,
+				publicKey,
+				threshold,
+				groupSize,
+				verificationShares(signers),
+			)
+
+			commitments := executeRound1(t, signers)
+			signatureShares := executeRound2(t, signers, message, commitments)
+			signature, err := coordinator.Aggregate(message, commitments, signatureShares)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			serialized, err := ciphersuite.SerializeSignature(signature)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutils.AssertIntsEqual(t, "signature length", 64, len(serialized))
+
+			valid, err := ciphersuite.VerifySignatureBytes(
+				serialized,
+				ciphersuite.EncodePoint(publicKey),
+				message,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutils.AssertBoolsEqual(t, "signature validity", true, valid)
+		})
+	}
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
@@ -24,6 +24,16 @@ type Ciphersuite interface {
 		publicKey *Point,
 		message []byte,
 	) (bool, error)
+
+	// RequiresEvenY returns true if the signature verification accepts only
+	// the group public key and the group commitment with an even Y
+	// coordinate, as [BIP-340] does by treating points as their X coordinate.
+	// Participants of such a ciphersuite negate their secret key share when
+	// the group public key has an odd Y coordinate and their nonces when the
+	// group commitment has an odd Y coordinate. The coordinator negates the
+	// group commitment and the respective verification and commitment shares
+	// accordingly.
+	RequiresEvenY() bool
 }
 
 // Hashing interface abstracts out hash functions implementations specific to the
@@ -44,6 +54,18 @@ type Hashing interface {
 	H4(m []byte) []byte
 	H5(m []byte) []byte
 
+	// HDKG is the hash function used to compute the challenge of the proof of
+	// knowledge in the distributed key generation. It is not defined by
+	// [FROST] but must be domain-separated from H1, H2, H3, H4, and H5 the
+	// same way they are domain-separated from each other.
+	HDKG(m []byte, ms ...[]byte) *big.Int
+
+	// HAuth is the hash function used to compute the challenge of the
+	// signer's authentication of a message. It is not defined by [FROST] but
+	// must be domain-separated from all other hash functions the same way
+	// HDKG is.
+	HAuth(m []byte, ms ...[]byte) *big.Int
+
 	// EncodePoint encodes the given elliptic curve point to a byte slice in
 	// a way that is *specific* to the given ciphersuite needs. This is
 	// especially important when calculating a signature challenge in [FROST].
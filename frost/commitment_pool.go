@@ -0,0 +1,191 @@
+package frost
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// CommitmentPool is a coordinator-side pool of nonce commitments published by
+// signers ahead of signing, as allowed by [FROST] section 5.1. Round One -
+// Commitment. The coordinator takes one commitment of every signer per
+// signing session so that the signing requires just Round Two from the
+// coordinator's point of view. The pool accepts only commitments of members
+// of the group, that is, of signers with identifiers from 1 to the group
+// size.
+//
+// Commitments expire after the pool's time-to-live so that the coordinator
+// does not start a session with a commitment the signer may have already
+// discarded. Every commitment can be taken from the pool only once. The pool
+// remembers every commitment taken and rejects the commitment if it is added
+// again. CommitmentPool is safe for concurrent use.
+type CommitmentPool struct {
+	curve     Curve
+	groupSize int
+	ttl       time.Duration
+	now       func() time.Time
+
+	mutex       sync.Mutex
+	commitments map[uint64][]*pooledCommitment // by signer, oldest first
+	known       map[string]bool                // keys of pooled and taken commitments
+}
+
+type pooledCommitment struct {
+	commitment *NonceCommitment
+	expiresAt  time.Time
+}
+
+// NewCommitmentPool creates a new, empty CommitmentPool for the group of the
+// given size. Commitments expire after the given time-to-live counted from
+// the moment they were added.
+func NewCommitmentPool(
+	curve Curve,
+	groupSize int,
+	ttl time.Duration,
+) *CommitmentPool {
+	return &CommitmentPool{
+		curve:       curve,
+		groupSize:   groupSize,
+		ttl:         ttl,
+		now:         time.Now,
+		commitments: make(map[uint64][]*pooledCommitment),
+		known:       make(map[string]bool),
+	}
+}
+
+// Add adds commitments published by signers to the pool. Commitments are
+// validated first and either all or none of them are added. The function
+// returns an error if any commitment is not valid, if it was issued by
+// a signer not being a member of the group, or if it was already added to
+// the pool, no matter if it was taken since then.
+func (cp *CommitmentPool) Add(commitments ...*NonceCommitment) error {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	keys := make([]string, len(commitments))
+	batch := make(map[string]bool, len(commitments))
+	for i, commitment := range commitments {
+		if commitment == nil {
+			return fmt.Errorf("commitment [%d] is nil", i)
+		}
+
+		if commitment.signerIndex == 0 ||
+			commitment.signerIndex > uint64(cp.groupSize) {
+			return fmt.Errorf(
+				"commitment [%d] of signer [%d] is not issued by a member "+
+					"of the group of size [%d]",
+				i,
+				commitment.signerIndex,
+				cp.groupSize,
+			)
+		}
+
+		key, err := nonceKey(commitment)
+		if err != nil {
+			return fmt.Errorf("commitment [%d]: [%v]", i, err)
+		}
+
+		if !cp.curve.IsPointOnCurve(commitment.hidingNonceCommitment) ||
+			!cp.curve.IsPointOnCurve(commitment.bindingNonceCommitment) {
+			return fmt.Errorf(
+				"commitment [%d] of signer [%d] is not a valid pair of "+
+					"non-identity points on the curve",
+				i,
+				commitment.signerIndex,
+			)
+		}
+
+		if cp.known[key] || batch[key] {
+			return fmt.Errorf(
+				"commitment [%d] of signer [%d] was already added",
+				i,
+				commitment.signerIndex,
+			)
+		}
+
+		keys[i] = key
+		batch[key] = true
+	}
+
+	expiresAt := cp.now().Add(cp.ttl)
+	for i, commitment := range commitments {
+		cp.commitments[commitment.signerIndex] = append(
+			cp.commitments[commitment.signerIndex],
+			&pooledCommitment{
+				commitment: commitment,
+				expiresAt:  expiresAt,
+			},
+		)
+		cp.known[keys[i]] = true
+	}
+
+	return nil
+}
+
+// Take takes the oldest unexpired commitment of every signer from the pool
+// and returns the commitments sorted by signer identifier, ready to be used
+// as the commitment list of a signing session. If any of the signers has no
+// unexpired commitment in the pool, the function returns an error and no
+// commitment is taken.
+func (cp *CommitmentPool) Take(signers []uint64) ([]*NonceCommitment, error) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	cp.prune()
+
+	sorted := slices.Clone(signers)
+	slices.Sort(sorted)
+
+	for i, signerIndex := range sorted {
+		if i > 0 && sorted[i-1] == signerIndex {
+			return nil, fmt.Errorf("signer [%d] is duplicated", signerIndex)
+		}
+		if len(cp.commitments[signerIndex]) == 0 {
+			return nil, fmt.Errorf(
+				"no commitment of signer [%d] in the pool",
+				signerIndex,
+			)
+		}
+	}
+
+	commitments := make([]*NonceCommitment, len(sorted))
+	for i, signerIndex := range sorted {
+		pooled := cp.commitments[signerIndex]
+		commitments[i] = pooled[0].commitment
+		cp.commitments[signerIndex] = pooled[1:]
+	}
+
+	return commitments, nil
+}
+
+// Available returns the number of unexpired commitments of the signer in the
+// pool.
+func (cp *CommitmentPool) Available(signerIndex uint64) int {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	cp.prune()
+
+	return len(cp.commitments[signerIndex])
+}
+
+// prune removes expired commitments from the pool. Expired commitments stay
+// known to the pool so that they can not be added again.
+func (cp *CommitmentPool) prune() {
+	now := cp.now()
+	for signerIndex, pooled := range cp.commitments {
+		// Commitments of a signer are sorted by the expiry time as they are
+		// appended in the order they were added.
+		expired := 0
+		for expired < len(pooled) && !now.Before(pooled[expired].expiresAt) {
+			expired++
+		}
+
+		if expired == len(pooled) {
+			delete(cp.commitments, signerIndex)
+		} else if expired > 0 {
+			cp.commitments[signerIndex] = pooled[expired:]
+		}
+	}
+}
@@ -0,0 +1,326 @@
+package frost
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"threshold.network/roast/internal/testutils"
+)
+
+func TestCommitmentPool(t *testing.T) {
+	pool := NewCommitmentPool(ciphersuite.Curve(), groupSize, time.Hour)
+
+	first := newPoolCommitment(1, 1)
+	second := newPoolCommitment(1, 2)
+	third := newPoolCommitment(2, 3)
+
+	if err := pool.Add(first, second, third); err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertIntsEqual(t, "available commitments of signer 1", 2, pool.Available(1))
+	testutils.AssertIntsEqual(t, "available commitments of signer 2", 1, pool.Available(2))
+
+	commitments, err := pool.Take([]uint64{2, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertIntsEqual(t, "number of commitments", 2, len(commitments))
+	if commitments[0] != first || commitments[1] != third {
+		t.Error("expected the oldest commitments sorted by signer")
+	}
+
+	_, err = pool.Take([]uint64{1, 2})
+	testutils.AssertStringsEqual(
+		t,
+		"take error",
+		"no commitment of signer [2] in the pool",
+		err.Error(),
+	)
+	// The failed take must not consume the commitment of signer 1.
+	testutils.AssertIntsEqual(t, "available commitments of signer 1", 1, pool.Available(1))
+
+	commitments, err = pool.Take([]uint64{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commitments[0] != second {
+		t.Error("expected the second commitment of signer 1")
+	}
+	testutils.AssertIntsEqual(t, "available commitments of signer 1", 0, pool.Available(1))
+}
+
+func TestCommitmentPool_AddErrors(t *testing.T) {
+	curve := ciphersuite.Curve()
+
+	notOnCurve := newPoolCommitment(1, 1)
+	notOnCurve.bindingNonceCommitment = curve.Identity()
+
+	tests := map[string]struct {
+		pooled      []*NonceCommitment
+		added       []*NonceCommitment
+		expectedErr string
+	}{
+		"nil commitment": {
+			added:       []*NonceCommitment{newPoolCommitment(1, 1), nil},
+			expectedErr: "commitment [1] is nil",
+		},
+		"zero signer identifier": {
+			added: []*NonceCommitment{newPoolCommitment(0, 1)},
+			expectedErr: "commitment [0] of signer [0] is not issued by a member " +
+				"of the group of size [100]",
+		},
+		"signer identifier above the group size": {
+			added: []*NonceCommitment{
+				newPoolCommitment(1, 1),
+				newPoolCommitment(uint64(groupSize+1), 2),
+			},
+			expectedErr: "commitment [1] of signer [101] is not issued by a " +
+				"member of the group of size [100]",
+		},
+		"incomplete commitment": {
+			added: []*NonceCommitment{
+				{signerIndex: 1, hidingNonceCommitment: curve.EcBaseMul(big.NewInt(1))},
+			},
+			expectedErr: "commitment [0]: [nonce commitment is incomplete]",
+		},
+		"identity element": {
+			added: []*NonceCommitment{notOnCurve},
+			expectedErr: "commitment [0] of signer [1] is not a valid pair of " +
+				"non-identity points on the curve",
+		},
+		"commitment already in the pool": {
+			pooled:      []*NonceCommitment{newPoolCommitment(1, 1)},
+			added:       []*NonceCommitment{newPoolCommitment(2, 2), newPoolCommitment(1, 1)},
+			expectedErr: "commitment [1] of signer [1] was already added",
+		},
+		"commitment duplicated in the batch": {
+			added:       []*NonceCommitment{newPoolCommitment(1, 1), newPoolCommitment(1, 1)},
+			expectedErr: "commitment [1] of signer [1] was already added",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			pool := NewCommitmentPool(curve, groupSize, time.Hour)
+			if err := pool.Add(test.pooled...); err != nil {
+				t.Fatal(err)
+			}
+
+			err := pool.Add(test.added...)
+			testutils.AssertStringsEqual(t, "add error", test.expectedErr, err.Error())
+
+			// None of the commitments is added if any of them is invalid.
+			testutils.AssertIntsEqual(
+				t,
+				"available commitments of signer 2",
+				0,
+				pool.Available(2),
+			)
+		})
+	}
+}
+
+func TestCommitmentPool_TakeErrors(t *testing.T) {
+	pool := NewCommitmentPool(ciphersuite.Curve(), groupSize, time.Hour)
+	if err := pool.Add(newPoolCommitment(1, 1), newPoolCommitment(2, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := pool.Take([]uint64{2, 1, 2})
+	testutils.AssertStringsEqual(t, "take error", "signer [2] is duplicated", err.Error())
+
+	_, err = pool.Take([]uint64{1, 3})
+	testutils.AssertStringsEqual(
+		t,
+		"take error",
+		"no commitment of signer [3] in the pool",
+		err.Error(),
+	)
+
+	testutils.AssertIntsEqual(t, "available commitments of signer 1", 1, pool.Available(1))
+	testutils.AssertIntsEqual(t, "available commitments of signer 2", 1, pool.Available(2))
+}
+
+func TestCommitmentPool_TakenCommitmentAddedAgain(t *testing.T) {
+	pool := NewCommitmentPool(ciphersuite.Curve(), groupSize, time.Hour)
+	if err := pool.Add(newPoolCommitment(1, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Take([]uint64{1}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := pool.Add(newPoolCommitment(1, 1))
+	testutils.AssertStringsEqual(
+		t,
+		"add error",
+		"commitment [0] of signer [1] was already added",
+		err.Error(),
+	)
+}
+
+func TestCommitmentPool_Expiry(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	pool := NewCommitmentPool(ciphersuite.Curve(), groupSize, time.Minute)
+	pool.now = func() time.Time { return now }
+
+	if err := pool.Add(newPoolCommitment(1, 1)); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(30 * time.Second)
+	if err := pool.Add(newPoolCommitment(1, 2)); err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertIntsEqual(t, "available commitments", 2, pool.Available(1))
+
+	now = now.Add(30 * time.Second)
+	testutils.AssertIntsEqual(t, "available commitments", 1, pool.Available(1))
+
+	commitments, err := pool.Take([]uint64{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBigIntsEqual(
+		t,
+		"hiding nonce commitment",
+		newPoolCommitment(1, 2).hidingNonceCommitment.X,
+		commitments[0].hidingNonceCommitment.X,
+	)
+
+	if err := pool.Add(newPoolCommitment(1, 3)); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(time.Minute)
+	_, err = pool.Take([]uint64{1})
+	testutils.AssertStringsEqual(
+		t,
+		"take error",
+		"no commitment of signer [1] in the pool",
+		err.Error(),
+	)
+
+	// The expired commitment can not be added back.
+	err = pool.Add(newPoolCommitment(1, 1))
+	testutils.AssertStringsEqual(
+		t,
+		"add error",
+		"commitment [0] of signer [1] was already added",
+		err.Error(),
+	)
+}
+
+func TestCommitmentPool_ConcurrentTake(t *testing.T) {
+	pool := NewCommitmentPool(ciphersuite.Curve(), groupSize, time.Hour)
+
+	count := 32
+	for i := 0; i < count; i++ {
+		err := pool.Add(
+			newPoolCommitment(1, int64(3*i+1)),
+			newPoolCommitment(2, int64(3*i+2)),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var mutex sync.Mutex
+	taken := make(map[*NonceCommitment]bool)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2*count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			commitments, err := pool.Take([]uint64{1, 2})
+			if err != nil {
+				return
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			for _, commitment := range commitments {
+				if taken[commitment] {
+					t.Errorf("commitment of signer [%d] taken twice", commitment.signerIndex)
+				}
+				taken[commitment] = true
+			}
+		}()
+	}
+	wg.Wait()
+
+	testutils.AssertIntsEqual(t, "number of taken commitments", 2*count, len(taken))
+}
+
+func TestCommitmentPool_Signing(t *testing.T) {
+	message := []byte("For even the very wise cannot see all ends")
+
+	signers := createSigners(t)[:threshold]
+	coordinator := NewCoordinator(
+		ciphersuite,
+		signers[0].publicKey,
+		threshold,
+		groupSize,
+		verificationShares(signers),
+	)
+
+	pool := NewCommitmentPool(ciphersuite.Curve(), groupSize, time.Hour)
+
+	signersByIndex := make(map[uint64]*Signer, len(signers))
+	signerIndexes := make([]uint64, len(signers))
+	for i, signer := range signers {
+		commitments, err := signer.Round1Batch(2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := pool.Add(commitments...); err != nil {
+			t.Fatal(err)
+		}
+
+		signersByIndex[signer.signerIndex] = signer
+		signerIndexes[i] = signer.signerIndex
+	}
+
+	for i := 0; i < 2; i++ {
+		commitments, err := pool.Take(signerIndexes)
+		if err != nil {
+			t.Fatalf("signing [%d]: [%v]", i, err)
+		}
+
+		signatureShares := make([]*big.Int, len(commitments))
+		for j, commitment := range commitments {
+			signer := signersByIndex[commitment.signerIndex]
+			signatureShares[j], err = signer.Round2(message, commitment, commitments)
+			if err != nil {
+				t.Fatalf("signing [%d]: [%v]", i, err)
+			}
+		}
+
+		signature, err := coordinator.Aggregate(message, commitments, signatureShares)
+		if err != nil {
+			t.Fatalf("signing [%d]: [%v]", i, err)
+		}
+
+		valid, err := ciphersuite.VerifySignature(
+			signature,
+			signers[0].publicKey,
+			message,
+		)
+		if err != nil {
+			t.Fatalf("signing [%d]: [%v]", i, err)
+		}
+		testutils.AssertBoolsEqual(t, "signature validity", true, valid)
+	}
+
+	if _, err := pool.Take(signerIndexes); err == nil {
+		t.Error("expected an error once the pool is drained")
+	}
+}
+
+func newPoolCommitment(signerIndex uint64, seed int64) *NonceCommitment {
+	commitment, _ := newTestNonce(seed)
+	commitment.signerIndex = signerIndex
+	return commitment
+}
@@ -1,6 +1,7 @@
 package frost
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -11,22 +12,29 @@ type Coordinator struct {
 	Participant
 	threshold int
 	groupSize int
+
+	verificationShares map[uint64]*Point // PK_i in [FROST], by identifier i
 }
 
-// NewCoordinator creates a new [FROST] Coordinator instance.
+// NewCoordinator creates a new [FROST] Coordinator instance. Verification
+// shares are the public keys PK_i = G.ScalarBaseMult(sk_i) of all group
+// members, indexed by the signer identifier i. They are used to verify
+// signature shares and identify signers who produced invalid ones.
 func NewCoordinator(
 	ciphersuite Ciphersuite,
 	publicKey *Point,
 	threshold int,
 	groupSize int,
+	verificationShares map[uint64]*Point,
 ) *Coordinator {
 	return &Coordinator{
 		Participant: Participant{
 			ciphersuite: ciphersuite,
 			publicKey:   publicKey,
 		},
-		threshold: threshold,
-		groupSize: groupSize,
+		threshold:          threshold,
+		groupSize:          groupSize,
+		verificationShares: verificationShares,
 	}
 }
 
@@ -34,11 +42,174 @@ func NewCoordinator(
 // 5.3. Signature Share Aggregation.
 //
 // Note that the signature produced by the signature share aggregation in
-// [FROST] may not be valid if there are malicious signers present.
+// [FROST] may not be valid if there are malicious signers present. Every
+// signature share should be verified with VerifySignatureShare before the
+// aggregation to identify signers who produced invalid shares.
 func (c *Coordinator) Aggregate(
 	message []byte,
 	commitments []*NonceCommitment,
 	signatureShares []*big.Int,
+) (*Signature, error) {
+	return c.AggregateContext(
+		context.Background(),
+		message,
+		commitments,
+		signatureShares,
+	)
+}
+
+// AggregateContext is like Aggregate but stops with the context error if the
+// context is done before the signature is aggregated. The context is checked
+// between the steps iterating over the commitment list.
+//
+// The observer is notified about the produced signature only if the signature
+// passes the verification. An invalid signature is still returned.
+func (c *Coordinator) AggregateContext(
+	ctx context.Context,
+	message []byte,
+	commitments []*NonceCommitment,
+	signatureShares []*big.Int,
+) (*Signature, error) {
+	signature, err := c.aggregate(ctx, message, commitments, signatureShares)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.observer != nil {
+		valid, _ := c.ciphersuite.VerifySignature(signature, c.signingKey(), message)
+		if valid {
+			c.notify(&Event{Kind: EventSignatureProduced})
+		}
+	}
+
+	return signature, nil
+}
+
+// AbortError is returned by AggregateIdentifiable when the aggregated
+// signature is invalid. The error lists signers who produced invalid
+// signature shares, sorted in ascending order, so that the signing can be
+// retried without them.
+type AbortError struct {
+	// Culprits are identifiers of signers who produced invalid signature
+	// shares.
+	Culprits []uint64
+	// Causes are verification errors of the signature shares of culprits,
+	// in the same order as culprits.
+	Causes []error
+}
+
+func (ae *AbortError) Error() string {
+	return fmt.Sprintf(
+		"aggregated signature is invalid; signers %v produced invalid "+
+			"signature shares: [%v]",
+		ae.Culprits,
+		errors.Join(ae.Causes...),
+	)
+}
+
+// AggregateIdentifiable aggregates signature shares like Aggregate and then
+// verifies the aggregated signature with the ciphersuite's VerifySignature.
+// If the signature is invalid, every signature share is verified with
+// VerifySignatureShare and an AbortError listing signers who produced
+// invalid shares is returned. This implements the identifiable abort
+// property of [FROST]: the coordinator can identify the misbehaving
+// participants and exclude them from the next signing attempt.
+//
+// Signature shares are verified only if the aggregated signature is invalid
+// so that the cost of verifying each share individually is paid only when
+// some of the signers misbehaved.
+func (c *Coordinator) AggregateIdentifiable(
+	message []byte,
+	commitments []*NonceCommitment,
+	signatureShares []*big.Int,
+) (*Signature, error) {
+	return c.AggregateIdentifiableContext(
+		context.Background(),
+		message,
+		commitments,
+		signatureShares,
+	)
+}
+
+// AggregateIdentifiableContext is like AggregateIdentifiable but stops with
+// the context error if the context is done before the signature is
+// aggregated or all signature shares are verified.
+func (c *Coordinator) AggregateIdentifiableContext(
+	ctx context.Context,
+	message []byte,
+	commitments []*NonceCommitment,
+	signatureShares []*big.Int,
+) (*Signature, error) {
+	if err := c.validateShareCount(commitments, signatureShares); err != nil {
+		return nil, err
+	}
+
+	// Invalid commitments make every share fail the verification. They are
+	// not attributable to signature shares and abort the aggregation.
+	validationErrors, _ := c.validateGroupCommitmentsBase(commitments)
+	if len(validationErrors) != 0 {
+		return nil, errors.Join(validationErrors...)
+	}
+
+	// Shares not being valid scalars can not be aggregated. There is no need
+	// to verify the aggregated signature to know it would be invalid.
+	allScalars := true
+	for _, zi := range signatureShares {
+		if !c.isScalar(zi) {
+			allScalars = false
+			break
+		}
+	}
+
+	if allScalars {
+		signature, err := c.aggregate(ctx, message, commitments, signatureShares)
+		if err != nil {
+			return nil, err
+		}
+
+		valid, _ := c.ciphersuite.VerifySignature(signature, c.signingKey(), message)
+		if valid {
+			c.notify(&Event{Kind: EventSignatureProduced})
+			return signature, nil
+		}
+	}
+
+	abortErr := &AbortError{}
+	for i, zi := range signatureShares {
+		signerIndex := commitments[i].signerIndex
+		_, err := c.VerifySignatureShareContext(
+			ctx,
+			signerIndex,
+			zi,
+			commitments,
+			message,
+		)
+		if err != nil && err == ctx.Err() {
+			return nil, err
+		}
+		if err != nil {
+			abortErr.Culprits = append(abortErr.Culprits, signerIndex)
+			abortErr.Causes = append(abortErr.Causes, err)
+		}
+	}
+
+	if len(abortErr.Culprits) == 0 {
+		return nil, fmt.Errorf(
+			"aggregated signature is invalid but all signature shares are valid",
+		)
+	}
+
+	return nil, abortErr
+}
+
+// aggregate is the signature share aggregation shared by Aggregate and
+// AggregateIdentifiable. It does not notify the observer about the produced
+// signature as it is not known yet whether the signature is valid.
+func (c *Coordinator) aggregate(
+	ctx context.Context,
+	message []byte,
+	commitments []*NonceCommitment,
+	signatureShares []*big.Int,
 ) (*Signature, error) {
 	// From [FROST]:
 	//
@@ -71,9 +242,86 @@ func (c *Coordinator) Aggregate(
 	//    - (R, z), a Schnorr signature consisting of an Element R and
 	//      Scalar z.
 
+	if err := c.validateShareCount(commitments, signatureShares); err != nil {
+		return nil, err
+	}
+
+	// Every signature share must be a valid Scalar, as DeserializeScalar
+	// would require.
+	for i, zi := range signatureShares {
+		if !c.isScalar(zi) {
+			return nil, fmt.Errorf(
+				"signature share of signer [%d] is not a valid scalar",
+				commitments[i].signerIndex,
+			)
+		}
+	}
+
+	validationErrors, _ := c.validateGroupCommitmentsBase(commitments)
+	if len(validationErrors) != 0 {
+		return nil, errors.Join(validationErrors...)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// binding_factor_list = compute_binding_factors(group_public_key, commitment_list, msg)
+	bindingFactors := c.computeBindingFactors(message, commitments)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// group_commitment = compute_group_commitment(commitment_list, binding_factor_list)
+	groupCommitment := c.computeGroupCommitment(commitments, bindingFactors)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	curve := c.ciphersuite.Curve()
+	curveOrder := curve.Order()
+
+	// Signers negated their nonces if the group commitment has an odd Y
+	// coordinate and the ciphersuite requires an even one. The signature
+	// must use the negated group commitment then.
+	if c.hasOddY(groupCommitment) {
+		groupCommitment = curve.EcSub(curve.Identity(), groupCommitment)
+	}
+
+	// z = Scalar(0)
+	z := big.NewInt(0)
+	// for z_i in sig_shares:
+	//     z = z + z_i
+	for _, zi := range signatureShares {
+		z.Add(z, zi)
+		z.Mod(z, curveOrder)
+	}
+
+	// The tweak is public and known to all participants. It is added to the
+	// signature once, by the coordinator, and not by every signer.
+	if c.tweak != nil {
+		// challenge = compute_challenge(group_commitment, group_public_key, msg)
+		challenge := c.computeChallenge(message, groupCommitment)
+		z.Add(z, c.tweakTerm(challenge))
+		z.Mod(z, curveOrder)
+	}
+
+	// return (group_commitment, z)
+	return &Signature{groupCommitment, z}, nil
+}
+
+// validateShareCount validates the number of signature shares against the
+// threshold and the group size and checks there is a commitment for every
+// signature share.
+func (c *Coordinator) validateShareCount(
+	commitments []*NonceCommitment,
+	signatureShares []*big.Int,
+) error {
 	// MIN_PARTICIPANTS <= NUM_PARTICIPANTS
 	if len(signatureShares) < c.threshold {
-		return nil, fmt.Errorf(
+		return fmt.Errorf(
 			"not enough shares; has [%d] for threshold [%d]",
 			len(signatureShares),
 			c.threshold,
@@ -82,7 +330,7 @@ func (c *Coordinator) Aggregate(
 
 	// NUM_PARTICIPANTS <= MAX_PARTICIPANTS
 	if len(signatureShares) > c.groupSize {
-		return nil, fmt.Errorf(
+		return fmt.Errorf(
 			"too many shares; has [%d] for group size [%d]",
 			len(signatureShares),
 			c.groupSize,
@@ -90,7 +338,7 @@ func (c *Coordinator) Aggregate(
 	}
 
 	if len(commitments) != len(signatureShares) {
-		return nil, fmt.Errorf(
+		return fmt.Errorf(
 			"the number of commitments and signature shares do not match; "+
 				"has [%d] commitments and [%d] signature shares",
 			len(commitments),
@@ -98,29 +346,223 @@ func (c *Coordinator) Aggregate(
 		)
 	}
 
-	validationErrors, _ := c.validateGroupCommitmentsBase(commitments)
+	return nil
+}
+
+// isScalar returns true if the signature share can be deserialized as
+// a Scalar, that is, it is in the range [0, p-1].
+func (c *Coordinator) isScalar(signatureShare *big.Int) bool {
+	return signatureShare != nil &&
+		signatureShare.Sign() >= 0 &&
+		signatureShare.Cmp(c.ciphersuite.Curve().Order()) < 0
+}
+
+// VerifySignatureShare implements Signature Share Verification from [FROST],
+// section 5.4. Signature Share Verification. The function returns true and
+// nil error when the signature share is valid. The function returns false and
+// an error when the signature share is invalid. The error provides a detailed
+// explanation on why the signature share verification failed.
+//
+// The share is verified against the verification share of the signer
+// provided to the coordinator when it was created.
+func (c *Coordinator) VerifySignatureShare(
+	signerIndex uint64,
+	signatureShare *big.Int,
+	commitments []*NonceCommitment,
+	message []byte,
+) (bool, error) {
+	return c.VerifySignatureShareContext(
+		context.Background(),
+		signerIndex,
+		signatureShare,
+		commitments,
+		message,
+	)
+}
+
+// VerifySignatureShareContext is like VerifySignatureShare but stops with the
+// context error if the context is done before the verification completes.
+// The context is checked between the steps iterating over the commitment
+// list. The share is not considered rejected if the verification was stopped.
+func (c *Coordinator) VerifySignatureShareContext(
+	ctx context.Context,
+	signerIndex uint64,
+	signatureShare *big.Int,
+	commitments []*NonceCommitment,
+	message []byte,
+) (bool, error) {
+	valid, err := c.verifySignatureShare(
+		ctx,
+		signerIndex,
+		signatureShare,
+		commitments,
+		message,
+	)
+	if err != nil && err == ctx.Err() {
+		return false, err
+	}
+	if err != nil {
+		c.notify(&Event{
+			Kind:        EventShareRejected,
+			SignerIndex: signerIndex,
+			Reason:      err,
+		})
+		return valid, err
+	}
+
+	c.notify(&Event{Kind: EventShareReceived, SignerIndex: signerIndex})
+
+	return valid, nil
+}
+
+func (c *Coordinator) verifySignatureShare(
+	ctx context.Context,
+	signerIndex uint64,
+	signatureShare *big.Int,
+	commitments []*NonceCommitment,
+	message []byte,
+) (bool, error) {
+	// From [FROST]:
+	//
+	// 5.4.  Signature Share Verification
+	//
+	//   Having received a signature share, the Coordinator can verify
+	//   the share for correctness. If verification fails, the Coordinator
+	//   can identify the signer who produced the invalid share.
+	//
+	//   Inputs:
+	//     - identifier, identifier i of the participant, a NonZeroScalar.
+	//     - PK_i, the public key for the i-th participant, where
+	//       PK_i = G.ScalarBaseMult(sk_i), an Element.
+	//     - comm_i, pair of Element values
+	//       (hiding_nonce_commitment, binding_nonce_commitment) generated in
+	//       round one from the i-th participant.
+	//     - sig_share_i, a Scalar value indicating the signature share as
+	//       produced in round two from the i-th participant.
+	//     - commitment_list = [(j, hiding_nonce_commitment_j,
+	//       binding_nonce_commitment_j), ...], a list of commitments issued by
+	//       each participant, where each element in the list indicates a
+	//       NonZeroScalar identifier j and two commitment Element values
+	//       (hiding_nonce_commitment_j, binding_nonce_commitment_j). This list
+	//       MUST be sorted in ascending order by identifier.
+	//     - group_public_key, public key corresponding to the group signing
+	//       key, an Element.
+	//     - msg, the message to be signed, a byte string.
+	//
+	//   Outputs:
+	//     - True if the signature share is valid, and False otherwise.
+
+	curve := c.ciphersuite.Curve()
+
+	// PK_i
+	verificationShare, ok := c.verificationShares[signerIndex]
+	if !ok {
+		return false, fmt.Errorf(
+			"verification share of signer [%d] is unknown",
+			signerIndex,
+		)
+	}
+	if verificationShare == nil || !curve.IsPointOnCurve(verificationShare) {
+		return false, fmt.Errorf(
+			"verification share of signer [%d] is not a valid non-identity "+
+				"point on the curve",
+			signerIndex,
+		)
+	}
+
+	// The signature share must be a valid Scalar, as DeserializeScalar
+	// would require.
+	if signatureShare == nil {
+		return false, fmt.Errorf("signature share of signer [%d] is nil", signerIndex)
+	}
+	if signatureShare.Sign() < 0 || signatureShare.Cmp(curve.Order()) >= 0 {
+		return false, fmt.Errorf(
+			"signature share of signer [%d] is not a valid scalar",
+			signerIndex,
+		)
+	}
+
+	validationErrors, participants := c.validateGroupCommitmentsBase(commitments)
 	if len(validationErrors) != 0 {
-		return nil, errors.Join(validationErrors...)
+		return false, errors.Join(validationErrors...)
+	}
+
+	// comm_i = (hiding_nonce_commitment, binding_nonce_commitment)
+	var commitment *NonceCommitment
+	for _, nc := range commitments {
+		if nc.signerIndex == signerIndex {
+			commitment = nc
+			break
+		}
+	}
+	if commitment == nil {
+		return false, fmt.Errorf(
+			"commitment of signer [%d] not found on the list",
+			signerIndex,
+		)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return false, err
 	}
 
 	// binding_factor_list = compute_binding_factors(group_public_key, commitment_list, msg)
 	bindingFactors := c.computeBindingFactors(message, commitments)
+	// binding_factor = binding_factor_for_participant(binding_factor_list, identifier)
+	bindingFactor := bindingFactors[signerIndex]
+
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
 
 	// group_commitment = compute_group_commitment(commitment_list, binding_factor_list)
 	groupCommitment := c.computeGroupCommitment(commitments, bindingFactors)
 
-	curve := c.ciphersuite.Curve()
-	curveOrder := curve.Order()
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
 
-	// z = Scalar(0)
-	z := big.NewInt(0)
-	// for z_i in sig_shares:
-	//     z = z + z_i
-	for _, zi := range signatureShares {
-		z.Add(z, zi)
-		z.Mod(z, curveOrder)
+	// comm_share = hiding_nonce_commitment + G.ScalarMult(
+	//     binding_nonce_commitment, binding_factor)
+	commShare := curve.EcAdd(
+		commitment.hidingNonceCommitment,
+		curve.EcMul(commitment.bindingNonceCommitment, bindingFactor),
+	)
+	// The signer negated its nonces if the group commitment has an odd Y
+	// coordinate and the ciphersuite requires an even one.
+	if c.hasOddY(groupCommitment) {
+		commShare = curve.EcSub(curve.Identity(), commShare)
+	}
+	// The signer negated its secret key share if the group public key or the
+	// tweaked public key has an odd Y coordinate and the ciphersuite requires
+	// an even one.
+	if c.negatesKeyShare() {
+		verificationShare = curve.EcSub(curve.Identity(), verificationShare)
 	}
 
-	// return (group_commitment, z)
-	return &Signature{groupCommitment, z}, nil
+	// challenge = compute_challenge(group_commitment, group_public_key, msg)
+	challenge := c.computeChallenge(message, groupCommitment)
+
+	// lambda_i = derive_interpolating_value(participant_list, identifier)
+	lambda := c.deriveInterpolatingValue(signerIndex, participants)
+
+	// l = G.ScalarBaseMult(sig_share_i)
+	l := curve.EcBaseMul(signatureShare)
+
+	// r = comm_share + G.ScalarMult(PK_i, challenge * lambda_i)
+	r := curve.EcAdd(
+		commShare,
+		curve.EcMul(verificationShare, new(big.Int).Mul(challenge, lambda)),
+	)
+
+	// return l == r
+	if l.X.Cmp(r.X) != 0 || l.Y.Cmp(r.Y) != 0 {
+		return false, fmt.Errorf(
+			"signature share of signer [%d] does not match the commitment "+
+				"and the verification share",
+			signerIndex,
+		)
+	}
+
+	return true, nil
 }
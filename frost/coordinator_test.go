@@ -1,7 +1,11 @@
 package frost
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math/big"
+	"slices"
 	"testing"
 
 	"threshold.network/roast/internal/testutils"
@@ -15,10 +19,16 @@ func TestAggregate_Failures(t *testing.T) {
 	signers := createSigners(t)
 	publicKey := signers[0].publicKey
 
-	nonces, commitments := executeRound1(t, signers)
-	signatureShares := executeRound2(t, signers, message, nonces, commitments)
+	commitments := executeRound1(t, signers)
+	signatureShares := executeRound2(t, signers, message, commitments)
 
-	coordinator := NewCoordinator(ciphersuite, publicKey, threshold, groupSize)
+	coordinator := NewCoordinator(
+		ciphersuite,
+		publicKey,
+		threshold,
+		groupSize,
+		verificationShares(signers),
+	)
 
 	tests := map[string]struct {
 		commitments     []*NonceCommitment
@@ -40,6 +50,22 @@ func TestAggregate_Failures(t *testing.T) {
 			signatureShares: append(signatureShares, signatureShares[0]),
 			expectedErr:     "too many shares; has [101] for group size [100]",
 		},
+		"signature share out of range": {
+			commitments: commitments[:threshold],
+			signatureShares: append(
+				[]*big.Int{ciphersuite.Curve().Order()},
+				signatureShares[1:threshold]...,
+			),
+			expectedErr: "signature share of signer [1] is not a valid scalar",
+		},
+		"nil signature share": {
+			commitments: commitments[:threshold],
+			signatureShares: append(
+				slices.Clone(signatureShares[:threshold-1]),
+				nil,
+			),
+			expectedErr: "signature share of signer [51] is not a valid scalar",
+		},
 	}
 
 	for testName, test := range tests {
@@ -63,3 +89,309 @@ func TestAggregate_Failures(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifySignatureShare(t *testing.T) {
+	message := []byte("For even the very wise cannot see all ends")
+
+	signers := createSigners(t)[:threshold]
+	publicKey := signers[0].publicKey
+
+	commitments := executeRound1(t, signers)
+	signatureShares := executeRound2(t, signers, message, commitments)
+
+	coordinator := NewCoordinator(
+		ciphersuite,
+		publicKey,
+		threshold,
+		groupSize,
+		verificationShares(signers),
+	)
+
+	for i, signer := range signers {
+		ok, err := coordinator.VerifySignatureShare(
+			signer.signerIndex,
+			signatureShares[i],
+			commitments,
+			message,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testutils.AssertBoolsEqual(t, "signature share validity", true, ok)
+	}
+}
+
+func TestCoordinator_ContextCancelled(t *testing.T) {
+	message := []byte("For even the very wise cannot see all ends")
+
+	signers := createSigners(t)[:threshold]
+	publicKey := signers[0].publicKey
+
+	commitments := executeRound1(t, signers)
+	signatureShares := executeRound2(t, signers, message, commitments)
+
+	observer := &recordingObserver{}
+	coordinator := NewCoordinator(
+		ciphersuite,
+		publicKey,
+		threshold,
+		groupSize,
+		verificationShares(signers),
+	)
+	coordinator.SetObserver(observer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := coordinator.VerifySignatureShareContext(
+		ctx,
+		signers[0].signerIndex,
+		signatureShares[0],
+		commitments,
+		message,
+	)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected verification error: [%v]", err)
+	}
+
+	_, err = coordinator.AggregateContext(ctx, message, commitments, signatureShares)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected aggregation error: [%v]", err)
+	}
+
+	// A share not verified because of the cancellation is not rejected.
+	testutils.AssertIntsEqual(t, "number of events", 0, len(observer.events))
+}
+
+func TestVerifySignatureShare_Failures(t *testing.T) {
+	message := []byte("For even the very wise cannot see all ends")
+
+	group := createSigners(t)
+	signers := group[:threshold]
+	publicKey := signers[0].publicKey
+
+	commitments := executeRound1(t, signers)
+	signatureShares := executeRound2(t, signers, message, commitments)
+
+	curve := ciphersuite.Curve()
+
+	signer := signers[2]
+	verificationShare := curve.EcBaseMul(signer.secretKeyShare)
+
+	tests := map[string]struct {
+		signerIndex       uint64
+		verificationShare *Point
+		signatureShare    *big.Int
+		message           []byte
+		expectedErr       string
+	}{
+		"share of another signer": {
+			signerIndex:       signer.signerIndex,
+			verificationShare: verificationShare,
+			signatureShare:    signatureShares[3],
+			message:           message,
+			expectedErr:       "signature share of signer [3] does not match the commitment and the verification share",
+		},
+		"different message": {
+			signerIndex:       signer.signerIndex,
+			verificationShare: verificationShare,
+			signatureShare:    signatureShares[2],
+			message:           []byte("dummy"),
+			expectedErr:       "signature share of signer [3] does not match the commitment and the verification share",
+		},
+		"share out of range": {
+			signerIndex:       signer.signerIndex,
+			verificationShare: verificationShare,
+			signatureShare:    curve.Order(),
+			message:           message,
+			expectedErr:       "signature share of signer [3] is not a valid scalar",
+		},
+		"nil share": {
+			signerIndex:       signer.signerIndex,
+			verificationShare: verificationShare,
+			signatureShare:    nil,
+			message:           message,
+			expectedErr:       "signature share of signer [3] is nil",
+		},
+		"invalid verification share": {
+			signerIndex:       signer.signerIndex,
+			verificationShare: &Point{big.NewInt(1), big.NewInt(2)},
+			signatureShare:    signatureShares[2],
+			message:           message,
+			expectedErr:       "verification share of signer [3] is not a valid non-identity point on the curve",
+		},
+		"signer not in the commitment list": {
+			signerIndex:       uint64(groupSize),
+			verificationShare: verificationShare,
+			signatureShare:    signatureShares[2],
+			message:           message,
+			expectedErr:       "commitment of signer [100] not found on the list",
+		},
+		"unknown verification share": {
+			signerIndex:       uint64(groupSize + 1),
+			verificationShare: nil,
+			signatureShare:    signatureShares[2],
+			message:           message,
+			expectedErr:       "verification share of signer [101] is unknown",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			shares := verificationShares(group)
+			if test.verificationShare != nil {
+				shares[test.signerIndex] = test.verificationShare
+			}
+			coordinator := NewCoordinator(
+				ciphersuite,
+				publicKey,
+				threshold,
+				groupSize,
+				shares,
+			)
+
+			ok, err := coordinator.VerifySignatureShare(
+				test.signerIndex,
+				test.signatureShare,
+				commitments,
+				test.message,
+			)
+			testutils.AssertBoolsEqual(t, "signature share validity", false, ok)
+			testutils.AssertStringsEqual(
+				t,
+				"signature share verification error",
+				test.expectedErr,
+				err.Error(),
+			)
+		})
+	}
+}
+
+func TestAggregateIdentifiable(t *testing.T) {
+	message := []byte("For even the very wise cannot see all ends")
+
+	signers := createSigners(t)[:threshold]
+	publicKey := signers[0].publicKey
+
+	commitments := executeRound1(t, signers)
+	signatureShares := executeRound2(t, signers, message, commitments)
+
+	coordinator := NewCoordinator(
+		ciphersuite,
+		publicKey,
+		threshold,
+		groupSize,
+		verificationShares(signers),
+	)
+
+	signature, err := coordinator.AggregateIdentifiable(
+		message,
+		commitments,
+		signatureShares,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid, err := ciphersuite.VerifySignature(signature, publicKey, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "signature validity", true, valid)
+}
+
+func TestAggregateIdentifiable_Culprits(t *testing.T) {
+	message := []byte("For even the very wise cannot see all ends")
+
+	signers := createSigners(t)[:threshold]
+	publicKey := signers[0].publicKey
+
+	commitments := executeRound1(t, signers)
+	signatureShares := executeRound2(t, signers, message, commitments)
+
+	tests := map[string]struct {
+		tamper           func(shares []*big.Int)
+		expectedCulprits []uint64
+	}{
+		"one invalid share": {
+			tamper: func(shares []*big.Int) {
+				shares[4] = new(big.Int).Add(shares[4], big.NewInt(1))
+			},
+			expectedCulprits: []uint64{5},
+		},
+		"invalid shares and shares not being scalars": {
+			tamper: func(shares []*big.Int) {
+				shares[1] = nil
+				shares[7] = new(big.Int).Add(shares[7], big.NewInt(1))
+				shares[9] = ciphersuite.Curve().Order()
+			},
+			expectedCulprits: []uint64{2, 8, 10},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			shares := make([]*big.Int, len(signatureShares))
+			copy(shares, signatureShares)
+			test.tamper(shares)
+
+			observer := &recordingObserver{}
+			coordinator := NewCoordinator(
+				ciphersuite,
+				publicKey,
+				threshold,
+				groupSize,
+				verificationShares(signers),
+			)
+			coordinator.SetObserver(observer)
+
+			signature, err := coordinator.AggregateIdentifiable(
+				message,
+				commitments,
+				shares,
+			)
+			if signature != nil {
+				t.Error("expected nil signature")
+			}
+
+			var abortErr *AbortError
+			if !errors.As(err, &abortErr) {
+				t.Fatalf("unexpected error: [%v]", err)
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"number of culprits",
+				len(test.expectedCulprits),
+				len(abortErr.Culprits),
+			)
+			for i, culprit := range test.expectedCulprits {
+				testutils.AssertUintsEqual(
+					t,
+					fmt.Sprintf("culprit [%d]", i),
+					culprit,
+					abortErr.Culprits[i],
+				)
+			}
+			testutils.AssertIntsEqual(
+				t,
+				"number of causes",
+				len(test.expectedCulprits),
+				len(abortErr.Causes),
+			)
+
+			testutils.AssertIntsEqual(
+				t,
+				"number of share rejected events",
+				len(test.expectedCulprits),
+				observer.count(EventShareRejected),
+			)
+			testutils.AssertIntsEqual(
+				t,
+				"number of signature produced events",
+				0,
+				observer.count(EventSignatureProduced),
+			)
+		})
+	}
+}
@@ -0,0 +1,378 @@
+package frost
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// KeyShare is the secret key share of a single participant produced by the
+// key generation: the pair (i, sk_i) from [FROST] Appendix C.1. Shamir Secret
+// Sharing. The secret key share must be kept secret by the participant.
+type KeyShare struct {
+	SignerIndex    uint64   // i in [FROST]
+	SecretKeyShare *big.Int // sk_i in [FROST]
+}
+
+// DealerOutput is the result of the trusted dealer key generation. The
+// dealer sends every participant their key share over a secure channel. The
+// group public key, the verification shares, and the VSS commitment are
+// public and should be broadcast to all participants and the coordinator.
+type DealerOutput struct {
+	// KeyShares are the secret key shares of all participants, sorted by the
+	// signer identifier.
+	KeyShares []*KeyShare
+	// PublicKey is the group public key PK.
+	PublicKey *Point
+	// VerificationShares are the public keys PK_i = G.ScalarBaseMult(sk_i)
+	// of all participants, indexed by the signer identifier i.
+	VerificationShares map[uint64]*Point
+	// VSSCommitment is the Feldman VSS commitment to the coefficients of the
+	// secret sharing polynomial, vss_commitment in [FROST]. The first element
+	// is the group public key.
+	VSSCommitment []*Point
+}
+
+// TrustedDealerKeygen implements def trusted_dealer_keygen(secret_key,
+// MAX_PARTICIPANTS, MIN_PARTICIPANTS) from [FROST] Appendix C. Trusted Dealer
+// Key Generation. The dealer splits the secret key into groupSize shares so
+// that any threshold of them can produce a signature.
+//
+// The dealer learns the secret key and all the secret key shares and should
+// erase them once the shares are delivered. Deployments that can not trust
+// a single party should use a distributed key generation instead.
+func TrustedDealerKeygen(
+	ciphersuite Ciphersuite,
+	secretKey *big.Int,
+	threshold int,
+	groupSize int,
+) (*DealerOutput, error) {
+	curve := ciphersuite.Curve()
+	order := curve.Order()
+
+	if secretKey == nil || secretKey.Sign() <= 0 || secretKey.Cmp(order) >= 0 {
+		return nil, fmt.Errorf("secret key is not a valid non-zero scalar")
+	}
+
+	// From [FROST] Appendix C.1. Shamir Secret Sharing, secret_share_shard:
+	//
+	//   if MIN_PARTICIPANTS > MAX_PARTICIPANTS:
+	//     raise "invalid parameters"
+	//   if MIN_PARTICIPANTS < 2:
+	//     raise "invalid parameters"
+	if threshold < 2 {
+		return nil, fmt.Errorf(
+			"threshold must be at least [2]; has [%d]",
+			threshold,
+		)
+	}
+	if threshold > groupSize {
+		return nil, fmt.Errorf(
+			"threshold [%d] is greater than the group size [%d]",
+			threshold,
+			groupSize,
+		)
+	}
+
+	// From [FROST] Appendix C. Trusted Dealer Key Generation:
+	//
+	// def trusted_dealer_keygen(
+	//   secret_key, MAX_PARTICIPANTS, MIN_PARTICIPANTS):
+
+	// # Generate random coefficients for the polynomial
+	// coefficients = []
+	// for i in range(0, MIN_PARTICIPANTS - 1):
+	//   coefficients.append(G.RandomScalar())
+	//
+	// secret_share_shard prepends the secret to the coefficients:
+	// coefficients = [s] + coefficients
+	coefficients := make([]*big.Int, threshold)
+	coefficients[0] = secretKey
+	for i := 1; i < threshold; i++ {
+		coefficient, err := randomScalar(curve)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not generate polynomial coefficient: [%v]",
+				err,
+			)
+		}
+		coefficients[i] = coefficient
+	}
+
+	// participant_private_keys, coefficients = secret_share_shard(
+	//   secret_key, coefficients, MAX_PARTICIPANTS)
+	keyShares := secretShareShard(curve, coefficients, groupSize)
+
+	// vss_commitment = vss_commit(coefficients):
+	vssCommitment := vssCommit(curve, coefficients)
+
+	// return participant_private_keys, vss_commitment[0], vss_commitment
+	publicKey, verificationShares, err := DeriveGroupInfo(
+		curve,
+		groupSize,
+		vssCommitment,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not derive group info: [%v]", err)
+	}
+
+	return &DealerOutput{
+		KeyShares:          keyShares,
+		PublicKey:          publicKey,
+		VerificationShares: verificationShares,
+		VSSCommitment:      vssCommitment,
+	}, nil
+}
+
+// VerifyKeyShare implements def vss_verify(share_i, vss_commitment) from
+// [FROST] Appendix C.2. Verifiable Secret Sharing. The function returns true
+// if the key share is consistent with the VSS commitment, that is, if the
+// participant received a valid share of the committed secret.
+func VerifyKeyShare(curve Curve, keyShare *KeyShare, vssCommitment []*Point) bool {
+	if keyShare == nil {
+		return false
+	}
+
+	_, err := vssVerify(
+		curve,
+		keyShare.SignerIndex,
+		keyShare.SecretKeyShare,
+		vssCommitment,
+	)
+	return err == nil
+}
+
+// verifySecretKeyShare is vssVerify additionally ensuring the signer is a
+// member of the group of the given size and the threshold does not exceed
+// the group size.
+func verifySecretKeyShare(
+	curve Curve,
+	signerIndex uint64,
+	groupSize int,
+	secretKeyShare *big.Int,
+	vssCommitment []*Point,
+) (*Point, error) {
+	if len(vssCommitment) > groupSize {
+		return nil, fmt.Errorf(
+			"VSS commitment of threshold [%d] is greater than the group size [%d]",
+			len(vssCommitment),
+			groupSize,
+		)
+	}
+	if signerIndex == 0 || signerIndex > uint64(groupSize) {
+		return nil, fmt.Errorf(
+			"signer [%d] is not a member of the group of size [%d]",
+			signerIndex,
+			groupSize,
+		)
+	}
+
+	return vssVerify(curve, signerIndex, secretKeyShare, vssCommitment)
+}
+
+// vssVerify implements def vss_verify(share_i, vss_commitment) from [FROST]
+// Appendix C.2. Verifiable Secret Sharing. The function returns the
+// verification share of the signer derived from the VSS commitment if the
+// secret key share is consistent with the VSS commitment and an error
+// explaining why it is not otherwise.
+func vssVerify(
+	curve Curve,
+	signerIndex uint64,
+	secretKeyShare *big.Int,
+	vssCommitment []*Point,
+) (*Point, error) {
+	if err := validateVSSCommitment(curve, vssCommitment); err != nil {
+		return nil, err
+	}
+	if signerIndex == 0 {
+		return nil, fmt.Errorf("signer identifier must be non-zero")
+	}
+	if secretKeyShare == nil || secretKeyShare.Sign() <= 0 ||
+		secretKeyShare.Cmp(curve.Order()) >= 0 {
+		return nil, fmt.Errorf("secret key share is not a valid non-zero scalar")
+	}
+
+	// From [FROST] Appendix C.2. Verifiable Secret Sharing:
+	//
+	// def vss_verify(share_i, vss_commitment)
+	//   (i, sk_i) = share_i
+	//   S_i = G.ScalarBaseMult(sk_i)
+	//   S_i' = G.Identity()
+	//   for j in range(0, MIN_PARTICIPANTS):
+	//     S_i' += G.ScalarMult(vss_commitment[j], pow(i, j))
+	//   return S_i == S_i'
+	verificationShare := evaluateVSSCommitment(curve, vssCommitment, signerIndex)
+	if !isPointEqual(curve.EcBaseMul(secretKeyShare), verificationShare) {
+		return nil, fmt.Errorf(
+			"secret key share of signer [%d] does not match the VSS commitment",
+			signerIndex,
+		)
+	}
+
+	return verificationShare, nil
+}
+
+// DeriveGroupInfo implements def derive_group_info(MAX_PARTICIPANTS,
+// MIN_PARTICIPANTS, vss_commitment) from [FROST] Appendix C.2. Verifiable
+// Secret Sharing. The function returns the group public key and the
+// verification shares of all participants, indexed by the signer identifier.
+// The threshold is the length of the VSS commitment.
+func DeriveGroupInfo(
+	curve Curve,
+	groupSize int,
+	vssCommitment []*Point,
+) (*Point, map[uint64]*Point, error) {
+	if err := validateVSSCommitment(curve, vssCommitment); err != nil {
+		return nil, nil, err
+	}
+	if len(vssCommitment) > groupSize {
+		return nil, nil, fmt.Errorf(
+			"VSS commitment of threshold [%d] is greater than the group size [%d]",
+			len(vssCommitment),
+			groupSize,
+		)
+	}
+
+	// PK = vss_commitment[0]
+	publicKey := vssCommitment[0]
+
+	// participant_public_keys = []
+	// for i in range(1, MAX_PARTICIPANTS+1):
+	//   PK_i = G.Identity()
+	//   for j in range(0, MIN_PARTICIPANTS):
+	//     PK_i += G.ScalarMult(vss_commitment[j], pow(i, j))
+	//   participant_public_keys.append(PK_i)
+	verificationShares := make(map[uint64]*Point, groupSize)
+	for i := uint64(1); i <= uint64(groupSize); i++ {
+		verificationShare := evaluateVSSCommitment(curve, vssCommitment, i)
+		if !curve.IsPointOnCurve(verificationShare) {
+			return nil, nil, fmt.Errorf(
+				"verification share of signer [%d] is the identity element",
+				i,
+			)
+		}
+		verificationShares[i] = verificationShare
+	}
+
+	// return PK, participant_public_keys
+	return publicKey, verificationShares, nil
+}
+
+// secretShareShard implements the evaluation of the polynomial for every
+// participant from def secret_share_shard(s, coefficients, MAX_PARTICIPANTS)
+// in [FROST] Appendix C.1. Shamir Secret Sharing. The coefficients must
+// already have the secret prepended.
+func secretShareShard(
+	curve Curve,
+	coefficients []*big.Int,
+	groupSize int,
+) []*KeyShare {
+	// # Evaluate the polynomial for each point x=1,...,n
+	// secret_key_shares = []
+	// for x_i in range(1, MAX_PARTICIPANTS + 1):
+	//   y_i = polynomial_evaluate(Scalar(x_i), coefficients)
+	//   secret_key_share_i = (x_i, y_i)
+	//   secret_key_shares.append(secret_key_share_i)
+	// return secret_key_shares, coefficients
+	keyShares := make([]*KeyShare, groupSize)
+	for i := range keyShares {
+		x := uint64(i + 1)
+		keyShares[i] = &KeyShare{
+			SignerIndex:    x,
+			SecretKeyShare: polynomialEvaluate(curve, x, coefficients),
+		}
+	}
+	return keyShares
+}
+
+// polynomialEvaluate implements def polynomial_evaluate(x, coeffs) from
+// [FROST] section 4.2. Polynomials, using Horner's method.
+func polynomialEvaluate(curve Curve, x uint64, coefficients []*big.Int) *big.Int {
+	order := curve.Order()
+	bigX := new(big.Int).SetUint64(x)
+
+	// value = Scalar(0)
+	value := big.NewInt(0)
+	// for coeff in reverse(coeffs):
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		// value *= x
+		value.Mul(value, bigX)
+		// value += coeff
+		value.Add(value, coefficients[i])
+		value.Mod(value, order)
+	}
+
+	// return value
+	return value
+}
+
+// vssCommit implements def vss_commit(coeffs) from [FROST] Appendix C.2.
+// Verifiable Secret Sharing.
+func vssCommit(curve Curve, coefficients []*big.Int) []*Point {
+	// vss_commitment = []
+	// for coeff in coeffs:
+	//   A_i = G.ScalarBaseMult(coeff)
+	//   vss_commitment.append(A_i)
+	// return vss_commitment
+	vssCommitment := make([]*Point, len(coefficients))
+	for i, coefficient := range coefficients {
+		vssCommitment[i] = curve.EcBaseMul(coefficient)
+	}
+	return vssCommitment
+}
+
+// evaluateVSSCommitment computes the sum of vss_commitment[j] * i^j over all
+// elements of the VSS commitment, that is, the public key of the participant
+// with the identifier i.
+func evaluateVSSCommitment(curve Curve, vssCommitment []*Point, i uint64) *Point {
+	order := curve.Order()
+	bigI := new(big.Int).SetUint64(i)
+
+	result := curve.Identity()
+	power := big.NewInt(1) // pow(i, j)
+	for _, commitment := range vssCommitment {
+		result = curve.EcAdd(result, curve.EcMul(commitment, power))
+		power = new(big.Int).Mul(power, bigI)
+		power.Mod(power, order)
+	}
+	return result
+}
+
+// validateVSSCommitment checks the VSS commitment has at least two elements
+// and all of them are valid, non-identity points on the curve.
+func validateVSSCommitment(curve Curve, vssCommitment []*Point) error {
+	if len(vssCommitment) < 2 {
+		return fmt.Errorf(
+			"VSS commitment must have at least [2] elements; has [%d]",
+			len(vssCommitment),
+		)
+	}
+
+	for j, commitment := range vssCommitment {
+		if commitment == nil || commitment.X == nil || commitment.Y == nil ||
+			!curve.IsPointOnCurve(commitment) {
+			return fmt.Errorf(
+				"VSS commitment element [%d] is not a valid non-identity "+
+					"point on the curve",
+				j,
+			)
+		}
+	}
+
+	return nil
+}
+
+// randomScalar implements G.RandomScalar() from [FROST] section 3.1.
+// Prime-Order Group, returning a random non-zero scalar.
+func randomScalar(curve Curve) (*big.Int, error) {
+	order := curve.Order()
+	for {
+		scalar, err := rand.Int(rand.Reader, order)
+		if err != nil {
+			return nil, err
+		}
+		if scalar.Sign() != 0 {
+			return scalar, nil
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package frost
+
+import (
+	"math/big"
+	"testing"
+
+	"threshold.network/roast/internal/testutils"
+)
+
+func TestTrustedDealerKeygen(t *testing.T) {
+	curve := ciphersuite.Curve()
+	secretKey := generateSecretKey(t, false)
+
+	output, err := TrustedDealerKeygen(ciphersuite, secretKey, 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "number of key shares", 5, len(output.KeyShares))
+	testutils.AssertIntsEqual(t, "VSS commitment length", 3, len(output.VSSCommitment))
+	testutils.AssertIntsEqual(
+		t,
+		"number of verification shares",
+		5,
+		len(output.VerificationShares),
+	)
+
+	testutils.AssertBytesEqual(
+		t,
+		curve.SerializePoint(curve.EcBaseMul(secretKey)),
+		curve.SerializePoint(output.PublicKey),
+	)
+	testutils.AssertBytesEqual(
+		t,
+		curve.SerializePoint(output.PublicKey),
+		curve.SerializePoint(output.VSSCommitment[0]),
+	)
+
+	for i, keyShare := range output.KeyShares {
+		testutils.AssertUintsEqual(t, "signer index", uint64(i+1), keyShare.SignerIndex)
+
+		if !VerifyKeyShare(curve, keyShare, output.VSSCommitment) {
+			t.Errorf("key share of signer [%d] is not valid", keyShare.SignerIndex)
+		}
+
+		testutils.AssertBytesEqual(
+			t,
+			curve.SerializePoint(curve.EcBaseMul(keyShare.SecretKeyShare)),
+			curve.SerializePoint(output.VerificationShares[keyShare.SignerIndex]),
+		)
+	}
+
+	// Any threshold of shares interpolates to the secret key.
+	participant := &Participant{ciphersuite: ciphersuite}
+	for _, L := range [][]uint64{{1, 2, 3}, {2, 4, 5}, {1, 3, 5}} {
+		secret := big.NewInt(0)
+		for _, i := range L {
+			term := new(big.Int).Mul(
+				participant.deriveInterpolatingValue(i, L),
+				output.KeyShares[i-1].SecretKeyShare,
+			)
+			secret.Add(secret, term)
+		}
+		secret.Mod(secret, curve.Order())
+
+		testutils.AssertBigIntsEqual(t, "interpolated secret key", secretKey, secret)
+	}
+}
+
+func TestTrustedDealerKeygen_Signing(t *testing.T) {
+	message := []byte("For even the very wise cannot see all ends")
+
+	output, err := TrustedDealerKeygen(
+		ciphersuite,
+		generateSecretKey(t, true),
+		threshold,
+		groupSize,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signers := make([]*Signer, threshold)
+	for i := range signers {
+		// The last threshold of members takes part in the signing.
+		keyShare := output.KeyShares[groupSize-threshold+i]
+		signers[i] = NewSigner(
+			ciphersuite,
+			keyShare.SignerIndex,
+			output.PublicKey,
+			threshold,
+			groupSize,
+			keyShare.SecretKeyShare,
+		)
+	}
+
+	coordinator := NewCoordinator(
+		ciphersuite,
+		output.PublicKey,
+		threshold,
+		groupSize,
+		output.VerificationShares,
+	)
+
+	commitments := executeRound1(t, signers)
+	signatureShares := executeRound2(t, signers, message, commitments)
+
+	signature, err := coordinator.AggregateIdentifiable(
+		message,
+		commitments,
+		signatureShares,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid, err := ciphersuite.VerifySignature(signature, output.PublicKey, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "signature validity", true, valid)
+}
+
+func TestTrustedDealerKeygen_Failures(t *testing.T) {
+	order := ciphersuite.Curve().Order()
+
+	tests := map[string]struct {
+		secretKey   *big.Int
+		threshold   int
+		groupSize   int
+		expectedErr string
+	}{
+		"nil secret key": {
+			secretKey:   nil,
+			threshold:   2,
+			groupSize:   3,
+			expectedErr: "secret key is not a valid non-zero scalar",
+		},
+		"zero secret key": {
+			secretKey:   big.NewInt(0),
+			threshold:   2,
+			groupSize:   3,
+			expectedErr: "secret key is not a valid non-zero scalar",
+		},
+		"secret key equal to the group order": {
+			secretKey:   order,
+			threshold:   2,
+			groupSize:   3,
+			expectedErr: "secret key is not a valid non-zero scalar",
+		},
+		"threshold lower than 2": {
+			secretKey:   big.NewInt(7),
+			threshold:   1,
+			groupSize:   3,
+			expectedErr: "threshold must be at least [2]; has [1]",
+		},
+		"threshold greater than the group size": {
+			secretKey:   big.NewInt(7),
+			threshold:   4,
+			groupSize:   3,
+			expectedErr: "threshold [4] is greater than the group size [3]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, err := TrustedDealerKeygen(
+				ciphersuite,
+				test.secretKey,
+				test.threshold,
+				test.groupSize,
+			)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			testutils.AssertStringsEqual(t, "keygen error", test.expectedErr, err.Error())
+		})
+	}
+}
+
+func TestVerifyKeyShare_Invalid(t *testing.T) {
+	curve := ciphersuite.Curve()
+
+	output, err := TrustedDealerKeygen(ciphersuite, big.NewInt(42), 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyShare := output.KeyShares[1]
+
+	tests := map[string]struct {
+		keyShare      *KeyShare
+		vssCommitment []*Point
+	}{
+		"share of another signer": {
+			keyShare: &KeyShare{
+				SignerIndex:    keyShare.SignerIndex + 1,
+				SecretKeyShare: keyShare.SecretKeyShare,
+			},
+			vssCommitment: output.VSSCommitment,
+		},
+		"modified share": {
+			keyShare: &KeyShare{
+				SignerIndex: keyShare.SignerIndex,
+				SecretKeyShare: new(big.Int).Add(
+					keyShare.SecretKeyShare,
+					big.NewInt(1),
+				),
+			},
+			vssCommitment: output.VSSCommitment,
+		},
+		"zero signer identifier": {
+			keyShare: &KeyShare{
+				SignerIndex:    0,
+				SecretKeyShare: keyShare.SecretKeyShare,
+			},
+			vssCommitment: output.VSSCommitment,
+		},
+		"truncated VSS commitment": {
+			keyShare:      keyShare,
+			vssCommitment: output.VSSCommitment[:1],
+		},
+		"VSS commitment with the identity element": {
+			keyShare: keyShare,
+			vssCommitment: []*Point{
+				output.VSSCommitment[0],
+				curve.Identity(),
+				output.VSSCommitment[2],
+			},
+		},
+		"nil key share": {
+			keyShare:      nil,
+			vssCommitment: output.VSSCommitment,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			testutils.AssertBoolsEqual(
+				t,
+				"key share validity",
+				false,
+				VerifyKeyShare(curve, test.keyShare, test.vssCommitment),
+			)
+		})
+	}
+}
+
+func TestDeriveGroupInfo_Failures(t *testing.T) {
+	curve := ciphersuite.Curve()
+
+	output, err := TrustedDealerKeygen(ciphersuite, big.NewInt(42), 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = DeriveGroupInfo(curve, 2, output.VSSCommitment)
+	testutils.AssertStringsEqual(
+		t,
+		"derive group info error",
+		"VSS commitment of threshold [3] is greater than the group size [2]",
+		err.Error(),
+	)
+
+	_, _, err = DeriveGroupInfo(curve, 5, output.VSSCommitment[:1])
+	testutils.AssertStringsEqual(
+		t,
+		"derive group info error",
+		"VSS commitment must have at least [2] elements; has [1]",
+		err.Error(),
+	)
+}
+
+func TestPolynomialEvaluate(t *testing.T) {
+	curve := ciphersuite.Curve()
+
+	// f(x) = 3 + 2x + x^2
+	coefficients := []*big.Int{big.NewInt(3), big.NewInt(2), big.NewInt(1)}
+
+	tests := map[string]struct {
+		x        uint64
+		expected *big.Int
+	}{
+		"f(1)":  {x: 1, expected: big.NewInt(6)},
+		"f(2)":  {x: 2, expected: big.NewInt(11)},
+		"f(10)": {x: 10, expected: big.NewInt(123)},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			testutils.AssertBigIntsEqual(
+				t,
+				"polynomial value",
+				test.expected,
+				polynomialEvaluate(curve, test.x, coefficients),
+			)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package frost
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// HardenedKeyStart is the index of the first hardened child key in [BIP-32].
+// Hardened child keys can not be derived from the public key so indexes
+// greater or equal to this one are not accepted by DeriveChildKey.
+//
+// [BIP-32]: https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki
+const HardenedKeyStart = uint32(0x80000000)
+
+// DerivedKey is a child public key derived from the group public key with
+// the unhardened [BIP-32] derivation.
+type DerivedKey struct {
+	// PublicKey is the derived child public key.
+	PublicKey *Point
+	// ChainCode is the chain code of the derived child key, allowing to
+	// derive further child keys.
+	ChainCode []byte
+	// Tweak is the additive scalar tweak t such that the child public key is
+	// the parent public key plus t*G. Signers and the coordinator should apply
+	// the tweak with ApplyTweak to produce signatures valid under the child
+	// public key.
+	Tweak *big.Int
+}
+
+// DeriveChildKey derives the child public key from the group public key and
+// the chain code along the path of unhardened [BIP-32] child key indexes.
+// For every index i on the path, the function implements the public parent
+// key to public child key derivation, CKDpub((K_par, c_par), i) from
+// [BIP-32]. The additive tweaks of all derivation steps are summed so that
+// the returned tweak is the tweak of the child key relative to the group
+// public key.
+//
+// The derivation uses only the Curve interface operations. The public key is
+// serialized in the compressed SEC1 format, as [BIP-32] requires.
+//
+// [BIP-32]: https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki
+func DeriveChildKey(
+	curve Curve,
+	publicKey *Point,
+	chainCode []byte,
+	path []uint32,
+) (*DerivedKey, error) {
+	if len(chainCode) != 32 {
+		return nil, fmt.Errorf(
+			"chain code must have 32 bytes; has [%d] bytes",
+			len(chainCode),
+		)
+	}
+	if publicKey == nil || !curve.IsPointOnCurve(publicKey) {
+		return nil, fmt.Errorf(
+			"public key is not a valid non-identity point on the curve",
+		)
+	}
+
+	order := curve.Order()
+
+	childKey := publicKey
+	childChainCode := chainCode
+	tweak := big.NewInt(0)
+
+	for depth, index := range path {
+		// From [BIP-32]:
+		//
+		// Public parent key → public child key
+		//
+		// The function CKDpub((K_par, c_par), i) → (K_i, c_i) computes a child
+		// extended public key from the parent extended public key. It is only
+		// defined for non-hardened child keys.
+		//
+		// - Check whether i ≥ 2^31 (whether the child is a hardened key).
+		//   - If so (hardened child): return failure
+		//   - If not (normal child): let I = HMAC-SHA512(Key = c_par,
+		//     Data = serP(K_par) || ser32(i)).
+		// - Split I into two 32-byte sequences, I_L and I_R.
+		// - The returned child key K_i is point(parse256(I_L)) + K_par.
+		// - The returned chain code c_i is I_R.
+		// - In case parse256(I_L) ≥ n or K_i is the point at infinity, the
+		//   resulting key is invalid, and one should proceed with the next
+		//   value for i.
+		if index >= HardenedKeyStart {
+			return nil, fmt.Errorf(
+				"index [%d] at depth [%d] is hardened; hardened child keys "+
+					"can not be derived from the public key",
+				index,
+				depth,
+			)
+		}
+
+		mac := hmac.New(sha512.New, childChainCode)
+		mac.Write(serializeCompressed(curve, childKey))
+		mac.Write(binary.BigEndian.AppendUint32(nil, index))
+		I := mac.Sum(nil)
+
+		IL := new(big.Int).SetBytes(I[:32])
+		if IL.Cmp(order) >= 0 {
+			return nil, fmt.Errorf(
+				"child key of index [%d] at depth [%d] is invalid",
+				index,
+				depth,
+			)
+		}
+
+		childKey = curve.EcAdd(curve.EcBaseMul(IL), childKey)
+		if !curve.IsPointOnCurve(childKey) {
+			return nil, fmt.Errorf(
+				"child key of index [%d] at depth [%d] is the point at infinity",
+				index,
+				depth,
+			)
+		}
+
+		childChainCode = I[32:]
+		tweak.Add(tweak, IL)
+		tweak.Mod(tweak, order)
+	}
+
+	return &DerivedKey{
+		PublicKey: childKey,
+		ChainCode: childChainCode,
+		Tweak:     tweak,
+	}, nil
+}
+
+// serializeCompressed implements serP(P) from [BIP-32]: the compressed SEC1
+// serialization of the point, (0x02 + parity of Y) || ser256(X). The length
+// of the X coordinate is the byte length of the group order which is the byte
+// length of the field for prime-order curves like secp256k1.
+func serializeCompressed(curve Curve, point *Point) []byte {
+	length := (curve.Order().BitLen() + 7) / 8
+
+	serialized := make([]byte, 1+length)
+	serialized[0] = byte(0x02 + point.Y.Bit(0))
+	point.X.FillBytes(serialized[1:])
+
+	return serialized
+}
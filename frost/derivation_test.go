@@ -0,0 +1,152 @@
+package frost
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"threshold.network/roast/internal/testutils"
+)
+
+func TestDeriveChildKey(t *testing.T) {
+	// Test vector 2 from [BIP-32]:
+	//
+	// Chain m
+	//   ext pub: xpub661MyMwAqRbcFW31YEwpkMuc5THy2PSt5bDMsktWQcFF8syAmRUapSCGu8ED9W6oDMSgv6Zz8idoc4a6mr8BDzTJY47LJhkJ8UB7WEGuduB
+	// Chain m/0
+	//   ext pub: xpub69H7F5d8KSRgmmdJg2KhpAK8SR3DjMwAdkxj3ZuxV27CprR9LgpeyGmXUbC6wb7ERfvrnKZjXoUmmDznezpbZb7ap6r1D3tgFxHmwMkQTPH
+	parentKey := "03cbcaa9c98c877a26977d00825c956a238e8dddfbd322cce4f74b0b5bd6ace4a7"
+	parentChainCode := "60499f801b896d83179a4374aeb7822aaeaceaa0db1f85ee3e904c4defbd9689"
+	childKey := "02fc9e5af0ac8d9b3cecfe2a888e2117ba3d089d8585886c9c826b6b22a98d12ea"
+	childChainCode := "f0909affaa7ee7abe5dd4e100598d4dc53cd709d5a5c2cac40e7412f232f7c9c"
+
+	curve := ciphersuite.Curve()
+	publicKey := parseCompressed(t, parentKey)
+	chainCode, err := hex.DecodeString(parentChainCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	derived, err := DeriveChildKey(curve, publicKey, chainCode, []uint32{0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertStringsEqual(
+		t,
+		"child public key",
+		childKey,
+		hex.EncodeToString(serializeCompressed(curve, derived.PublicKey)),
+	)
+	testutils.AssertStringsEqual(
+		t,
+		"child chain code",
+		childChainCode,
+		hex.EncodeToString(derived.ChainCode),
+	)
+
+	tweaked := curve.EcAdd(publicKey, curve.EcBaseMul(derived.Tweak))
+	testutils.AssertStringsEqual(
+		t,
+		"tweaked public key",
+		childKey,
+		hex.EncodeToString(serializeCompressed(curve, tweaked)),
+	)
+}
+
+func TestDeriveChildKey_Path(t *testing.T) {
+	curve := ciphersuite.Curve()
+	publicKey := curve.EcBaseMul(big.NewInt(10))
+	chainCode := ciphersuite.H4([]byte("chain code"))
+
+	path := []uint32{44, 0, 7}
+
+	derived, err := DeriveChildKey(curve, publicKey, chainCode, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Deriving step by step must yield the same key.
+	stepKey := publicKey
+	stepChainCode := chainCode
+	for _, index := range path {
+		step, err := DeriveChildKey(curve, stepKey, stepChainCode, []uint32{index})
+		if err != nil {
+			t.Fatal(err)
+		}
+		stepKey = step.PublicKey
+		stepChainCode = step.ChainCode
+	}
+
+	testutils.AssertBytesEqual(
+		t,
+		curve.SerializePoint(stepKey),
+		curve.SerializePoint(derived.PublicKey),
+	)
+	testutils.AssertBytesEqual(t, stepChainCode, derived.ChainCode)
+
+	// The tweak is relative to the group public key, not to the last parent.
+	tweaked := curve.EcAdd(publicKey, curve.EcBaseMul(derived.Tweak))
+	testutils.AssertBytesEqual(
+		t,
+		curve.SerializePoint(derived.PublicKey),
+		curve.SerializePoint(tweaked),
+	)
+}
+
+func TestDeriveChildKey_Failures(t *testing.T) {
+	curve := ciphersuite.Curve()
+	publicKey := curve.EcBaseMul(big.NewInt(10))
+	chainCode := ciphersuite.H4([]byte("chain code"))
+
+	tests := map[string]struct {
+		publicKey   *Point
+		chainCode   []byte
+		path        []uint32
+		expectedErr string
+	}{
+		"hardened index": {
+			publicKey:   publicKey,
+			chainCode:   chainCode,
+			path:        []uint32{0, HardenedKeyStart + 1},
+			expectedErr: "index [2147483649] at depth [1] is hardened; hardened child keys can not be derived from the public key",
+		},
+		"invalid chain code": {
+			publicKey:   publicKey,
+			chainCode:   chainCode[:31],
+			path:        []uint32{0},
+			expectedErr: "chain code must have 32 bytes; has [31] bytes",
+		},
+		"invalid public key": {
+			publicKey:   &Point{big.NewInt(1), big.NewInt(2)},
+			chainCode:   chainCode,
+			path:        []uint32{0},
+			expectedErr: "public key is not a valid non-identity point on the curve",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, err := DeriveChildKey(curve, test.publicKey, test.chainCode, test.path)
+			testutils.AssertStringsEqual(
+				t,
+				"derivation error",
+				test.expectedErr,
+				err.Error(),
+			)
+		})
+	}
+}
+
+func parseCompressed(t *testing.T, encoded string) *Point {
+	bytes, err := hex.DecodeString(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, err := btcec.ParsePubKey(bytes, btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Point{publicKey.X, publicKey.Y}
+}
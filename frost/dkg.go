@@ -0,0 +1,808 @@
+package frost
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// DKGParticipant represents a single participant of the distributed key
+// generation with a proof of knowledge, the KeyGen protocol from [FROST-DKG]
+// Figure 1, based on the Pedersen DKG. The protocol produces the same output
+// as TrustedDealerKeygen without any party ever learning the group secret
+// key.
+//
+// Every participant executes Round1 and broadcasts the result to all other
+// participants. Then, every participant executes Round2 with the packages
+// broadcast by others and sends every package returned to its receiver over
+// a secure, authenticated point-to-point channel. Every participant executes
+// VerifyShares with the packages received and broadcasts the complaints
+// returned. Every participant executes Justify with all complaints and
+// broadcasts the justifications returned. Finally, every participant
+// executes Finalize with all complaints and justifications.
+//
+// The complaint phase follows the Pedersen DKG: a dealer who sent an invalid
+// share either reveals a valid one publicly or is disqualified by all honest
+// participants, so the key generation does not abort because of a single
+// cheating dealer and all honest participants learn who cheated.
+//
+// The same rounds refresh the secret key shares of an existing group when the
+// participant is created with NewRefreshParticipant.
+//
+// [FROST-DKG]
+//
+//	Komlo C., Goldberg I., "FROST: Flexible Round-Optimized Schnorr Threshold
+//	Signatures", <https://eprint.iacr.org/2020/852.pdf>
+type DKGParticipant struct {
+	ciphersuite Ciphersuite
+
+	participantIndex uint64 // i in [FROST-DKG]
+	threshold        int    // t in [FROST-DKG]
+	groupSize        int    // n in [FROST-DKG]
+	context          []byte // Φ in [FROST-DKG]
+
+	coefficients []*big.Int // a_i0, ..., a_i(t-1), until Round2
+	commitment   []*Point   // C_i = <φ_i0, ..., φ_i(t-1)>
+
+	commitments map[uint64][]*Point // C_l of all participants, after Round2
+	sentShares  map[uint64]*big.Int // f_i(l) for all l, until Finalize
+
+	receivedShares map[uint64]*big.Int // f_l(i), from VerifyShares until Finalize
+	finalized      bool
+
+	refreshedShare      *big.Int // current s_i, only for the refresh
+	refreshedCommitment []*Point // current group VSS commitment, only for the refresh
+}
+
+// DKGRound1Package is the message broadcast by every participant in the
+// first round of the distributed key generation.
+type DKGRound1Package struct {
+	// ParticipantIndex is the identifier of the participant who produced the
+	// package.
+	ParticipantIndex uint64
+	// Commitment is the Feldman VSS commitment to the coefficients of the
+	// participant's secret polynomial, C_i = <φ_i0, ..., φ_i(t-1)>.
+	Commitment []*Point
+	// ProofOfKnowledge is the Schnorr proof of knowledge of the constant term
+	// of the secret polynomial, σ_i = (R_i, μ_i). It prevents rogue-key
+	// attacks where a participant chooses its commitment as a function of
+	// commitments of others. The proof is nil in the share refresh, where
+	// the constant term is zero.
+	ProofOfKnowledge *Signature
+}
+
+// DKGRound2Package is the message sent by the sender to the receiver in the
+// second round of the distributed key generation. The package holds the
+// secret share of the receiver and must be sent over a secure,
+// authenticated channel.
+type DKGRound2Package struct {
+	SenderIndex   uint64
+	ReceiverIndex uint64
+	SecretShare   *big.Int // f_sender(receiver)
+}
+
+// DKGOutput is the result of the distributed key generation for a single
+// participant. The group public key, the verification shares, and the VSS
+// commitment are the same for all honest participants.
+type DKGOutput struct {
+	// KeyShare is the participant's secret key share. It must be kept secret.
+	KeyShare *KeyShare
+	// PublicKey is the group public key.
+	PublicKey *Point
+	// VerificationShares are the public keys of all participants, indexed by
+	// the signer identifier.
+	VerificationShares map[uint64]*Point
+	// VSSCommitment is the group VSS commitment, the sum of commitments of
+	// all qualified participants. The first element is the group public key.
+	// Key shares can be verified against it with VerifyKeyShare.
+	VSSCommitment []*Point
+	// QualifiedParticipants are the identifiers of participants who were not
+	// disqualified in the complaint phase, sorted in ascending order. Only
+	// their secret polynomials contribute to the group secret key.
+	QualifiedParticipants []uint64
+}
+
+// DKGError is returned from the distributed key generation when some
+// participants sent invalid packages. The error lists the identifiers of
+// those participants, sorted in ascending order.
+type DKGError struct {
+	// Culprits are identifiers of participants who sent invalid packages.
+	Culprits []uint64
+	// Causes are the errors of packages of culprits, in the same order as
+	// culprits.
+	Causes []error
+}
+
+func (de *DKGError) Error() string {
+	return fmt.Sprintf(
+		"distributed key generation failed; participants %v sent invalid "+
+			"packages: [%v]",
+		de.Culprits,
+		errors.Join(de.Causes...),
+	)
+}
+
+// add records the culprit and the error of its package.
+func (de *DKGError) add(culprit uint64, cause error) {
+	de.Culprits = append(de.Culprits, culprit)
+	de.Causes = append(de.Causes, cause)
+}
+
+// NewDKGParticipant creates a new participant of the distributed key
+// generation. All participants must use the same threshold, group size, and
+// context. The context should be unique for every execution of the
+// distributed key generation, for example, a session identifier, so that
+// the proofs of knowledge can not be replayed across executions.
+func NewDKGParticipant(
+	ciphersuite Ciphersuite,
+	participantIndex uint64,
+	threshold int,
+	groupSize int,
+	context []byte,
+) (*DKGParticipant, error) {
+	if threshold < 2 {
+		return nil, fmt.Errorf(
+			"threshold must be at least [2]; has [%d]",
+			threshold,
+		)
+	}
+	if threshold > groupSize {
+		return nil, fmt.Errorf(
+			"threshold [%d] is greater than the group size [%d]",
+			threshold,
+			groupSize,
+		)
+	}
+	if participantIndex == 0 || participantIndex > uint64(groupSize) {
+		return nil, fmt.Errorf(
+			"participant [%d] is not a member of the group of size [%d]",
+			participantIndex,
+			groupSize,
+		)
+	}
+
+	return &DKGParticipant{
+		ciphersuite:      ciphersuite,
+		participantIndex: participantIndex,
+		threshold:        threshold,
+		groupSize:        groupSize,
+		context:          slices.Clone(context),
+	}, nil
+}
+
+// Round1 implements Round 1 of KeyGen from [FROST-DKG]. The returned package
+// must be broadcast to all other participants.
+func (dp *DKGParticipant) Round1() (*DKGRound1Package, error) {
+	if dp.commitment != nil {
+		return nil, fmt.Errorf("round one was already executed")
+	}
+
+	curve := dp.ciphersuite.Curve()
+	order := curve.Order()
+
+	// 1. Every participant P_i samples t random values
+	//    (a_i0, ..., a_i(t-1)) ← Z_q and uses these values as coefficients to
+	//    define a degree t - 1 polynomial f_i(x) = Σ a_ij x^j.
+	coefficients := make([]*big.Int, dp.threshold)
+	for j := range coefficients {
+		coefficient, err := randomScalar(curve)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not generate polynomial coefficient: [%v]",
+				err,
+			)
+		}
+		coefficients[j] = coefficient
+	}
+
+	// The refresh polynomial has the zero constant term so that the sum of
+	// the polynomials of all participants does not change the group secret
+	// key. There is no secret to prove the knowledge of.
+	if dp.isRefresh() {
+		coefficients[0] = big.NewInt(0)
+	}
+
+	// 3. Every participant P_i computes a public commitment
+	//    C_i = <φ_i0, ..., φ_i(t-1)>, where φ_ij = g^a_ij.
+	commitment := vssCommit(curve, coefficients)
+
+	if dp.isRefresh() {
+		dp.coefficients = coefficients
+		dp.commitment = commitment
+
+		return &DKGRound1Package{
+			ParticipantIndex: dp.participantIndex,
+			Commitment:       commitment,
+		}, nil
+	}
+
+	// 2. Every P_i computes a proof of knowledge to the corresponding secret
+	//    a_i0 by calculating σ_i = (R_i, μ_i), such that k ← Z_q,
+	//    R_i = g^k, c_i = H(i, Φ, g^a_i0, R_i), μ_i = k + a_i0 · c_i, with Φ
+	//    being a context string to prevent replay attacks.
+	k, err := randomScalar(curve)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate proof nonce: [%v]", err)
+	}
+	R := curve.EcBaseMul(k)
+	c := dp.proofChallenge(dp.participantIndex, commitment[0], R)
+	mu := new(big.Int).Mul(coefficients[0], c)
+	mu.Add(mu, k)
+	mu.Mod(mu, order)
+
+	dp.coefficients = coefficients
+	dp.commitment = commitment
+
+	// 4. Every P_i broadcasts C_i, σ_i to all other participants.
+	return &DKGRound1Package{
+		ParticipantIndex: dp.participantIndex,
+		Commitment:       commitment,
+		ProofOfKnowledge: &Signature{R: R, Z: mu},
+	}, nil
+}
+
+// Round2 implements the verification of proofs of knowledge from Round 1
+// and Round 2 of KeyGen from [FROST-DKG]. The function expects the Round 1
+// packages of all other participants. If any of the packages is invalid, the
+// function returns DKGError listing the participants who sent invalid
+// packages. Otherwise, the function returns the packages with secret shares
+// for all other participants, sorted by the receiver identifier. Every
+// package must be sent to its receiver over a secure, authenticated channel.
+//
+// The secret polynomial coefficients are erased once the shares are
+// computed.
+func (dp *DKGParticipant) Round2(
+	packages []*DKGRound1Package,
+) ([]*DKGRound2Package, error) {
+	if dp.coefficients == nil {
+		if dp.commitment == nil {
+			return nil, fmt.Errorf("round one was not executed")
+		}
+		return nil, fmt.Errorf("round two was already executed")
+	}
+
+	if len(packages) != dp.groupSize-1 {
+		return nil, fmt.Errorf(
+			"expected round one packages of [%d] other participants; has [%d]",
+			dp.groupSize-1,
+			len(packages),
+		)
+	}
+
+	commitments := map[uint64][]*Point{
+		dp.participantIndex: dp.commitment,
+	}
+
+	dkgErr := &DKGError{}
+	for i, p := range packages {
+		if p == nil {
+			return nil, fmt.Errorf("round one package [%d] is nil", i)
+		}
+		if err := dp.validateSender(p.ParticipantIndex, commitments); err != nil {
+			return nil, fmt.Errorf("round one package [%d]: [%v]", i, err)
+		}
+
+		// 5. Upon receiving C_l, σ_l from participants 1 ≤ l ≤ n, l ≠ i,
+		//    participant P_i verifies σ_l = (R_l, μ_l), aborting on failure,
+		//    by checking R_l ?= g^μ_l · φ_l0^-c_l, where
+		//    c_l = H(l, Φ, φ_l0, R_l).
+		if err := dp.verifyRound1Package(p); err != nil {
+			dkgErr.add(p.ParticipantIndex, err)
+			continue
+		}
+
+		commitments[p.ParticipantIndex] = p.Commitment
+	}
+
+	if len(dkgErr.Culprits) != 0 {
+		sortDKGError(dkgErr)
+		return nil, dkgErr
+	}
+
+	// 1. Each P_i securely sends to each other participant P_l a secret
+	//    share (l, f_i(l)), deleting f_i and each share afterward except
+	//    for (i, f_i(i)), which they keep for themselves.
+	//
+	// The shares are kept until Finalize so that the participant can reveal
+	// them in Justify if a receiver complains.
+	curve := dp.ciphersuite.Curve()
+	sentShares := make(map[uint64]*big.Int, dp.groupSize)
+	round2Packages := make([]*DKGRound2Package, 0, dp.groupSize-1)
+	for l := uint64(1); l <= uint64(dp.groupSize); l++ {
+		share := polynomialEvaluate(curve, l, dp.coefficients)
+		sentShares[l] = share
+		if l == dp.participantIndex {
+			continue
+		}
+		round2Packages = append(round2Packages, &DKGRound2Package{
+			SenderIndex:   dp.participantIndex,
+			ReceiverIndex: l,
+			SecretShare:   share,
+		})
+	}
+
+	for j := range dp.coefficients {
+		dp.coefficients[j] = nil
+	}
+	dp.coefficients = nil
+	dp.commitments = commitments
+	dp.sentShares = sentShares
+
+	return round2Packages, nil
+}
+
+// DKGComplaint is broadcast by the complainer to all participants when the
+// accused dealer sent the complainer no secret share or a secret share
+// inconsistent with the commitment the dealer broadcast in Round 1.
+type DKGComplaint struct {
+	ComplainerIndex uint64
+	AccusedIndex    uint64
+}
+
+// DKGJustification is broadcast by the accused dealer to all participants in
+// response to a complaint. The justification reveals the disputed secret
+// share so that every participant can verify it against the dealer's
+// commitment.
+type DKGJustification struct {
+	DealerIndex     uint64
+	ComplainerIndex uint64
+	SecretShare     *big.Int // f_dealer(complainer)
+}
+
+// VerifyShares implements the share verification from Round 2 of KeyGen from
+// [FROST-DKG], extended with the complaint phase of the Pedersen DKG. The
+// function expects the Round 2 packages sent to this participant by other
+// participants. Instead of aborting on an invalid share, the function
+// returns a complaint against every dealer who sent no secret share or a
+// secret share inconsistent with the dealer's commitment. The complaints,
+// even if there are none, must be broadcast to all other participants.
+func (dp *DKGParticipant) VerifyShares(
+	packages []*DKGRound2Package,
+) ([]*DKGComplaint, error) {
+	if dp.commitments == nil {
+		return nil, fmt.Errorf("round two was not executed")
+	}
+	if dp.receivedShares != nil {
+		return nil, fmt.Errorf("shares were already verified")
+	}
+
+	if len(packages) > dp.groupSize-1 {
+		return nil, fmt.Errorf(
+			"expected at most round two packages of [%d] other participants; "+
+				"has [%d]",
+			dp.groupSize-1,
+			len(packages),
+		)
+	}
+
+	senders := map[uint64][]*Point{dp.participantIndex: nil}
+	receivedShares := map[uint64]*big.Int{
+		dp.participantIndex: dp.sentShares[dp.participantIndex],
+	}
+	for i, p := range packages {
+		if p == nil {
+			return nil, fmt.Errorf("round two package [%d] is nil", i)
+		}
+		if err := dp.validateSender(p.SenderIndex, senders); err != nil {
+			return nil, fmt.Errorf("round two package [%d]: [%v]", i, err)
+		}
+		senders[p.SenderIndex] = nil
+
+		if p.ReceiverIndex != dp.participantIndex {
+			return nil, fmt.Errorf(
+				"round two package [%d] is for participant [%d]",
+				i,
+				p.ReceiverIndex,
+			)
+		}
+
+		// 2. Each P_i verifies their shares by calculating:
+		//    g^f_l(i) ?= Π φ_lk^(i^k mod q).
+		//
+		// Instead of aborting, P_i broadcasts a complaint against P_l.
+		if err := dp.verifyShare(
+			p.SenderIndex,
+			dp.participantIndex,
+			p.SecretShare,
+		); err != nil {
+			continue
+		}
+
+		receivedShares[p.SenderIndex] = p.SecretShare
+	}
+
+	complaints := make([]*DKGComplaint, 0)
+	for l := uint64(1); l <= uint64(dp.groupSize); l++ {
+		if _, ok := receivedShares[l]; !ok {
+			complaints = append(complaints, &DKGComplaint{
+				ComplainerIndex: dp.participantIndex,
+				AccusedIndex:    l,
+			})
+		}
+	}
+
+	dp.receivedShares = receivedShares
+
+	return complaints, nil
+}
+
+// Justify answers the complaints against this participant broadcast by
+// other participants. The function expects all complaints broadcast after
+// VerifyShares, including the ones against other participants, which are
+// ignored. For every complaint against this participant, the function
+// returns a justification revealing the disputed secret share. The
+// justifications, even if there are none, must be broadcast to all other
+// participants.
+func (dp *DKGParticipant) Justify(
+	complaints []*DKGComplaint,
+) ([]*DKGJustification, error) {
+	if dp.receivedShares == nil {
+		return nil, fmt.Errorf("shares were not verified")
+	}
+
+	justified := make(map[uint64]bool)
+	justifications := make([]*DKGJustification, 0)
+	for i, complaint := range complaints {
+		if err := dp.validateComplaint(complaint); err != nil {
+			return nil, fmt.Errorf("complaint [%d]: [%v]", i, err)
+		}
+		if complaint.AccusedIndex != dp.participantIndex ||
+			justified[complaint.ComplainerIndex] {
+			continue
+		}
+		justified[complaint.ComplainerIndex] = true
+
+		justifications = append(justifications, &DKGJustification{
+			DealerIndex:     dp.participantIndex,
+			ComplainerIndex: complaint.ComplainerIndex,
+			SecretShare:     dp.sentShares[complaint.ComplainerIndex],
+		})
+	}
+
+	return justifications, nil
+}
+
+// Finalize resolves the complaints and implements the key derivation from
+// Round 2 of KeyGen from [FROST-DKG]. The function expects all complaints
+// broadcast after VerifyShares and all justifications broadcast after
+// Justify. The caller must make sure every justification was broadcast by
+// the dealer it names.
+//
+// A dealer is disqualified if any complaint against it was not answered with
+// a justification revealing a secret share consistent with the dealer's
+// commitment. Since complaints and justifications are broadcast, all honest
+// participants disqualify the same dealers and agree on the qualified set,
+// the group public key, and the verification shares. If this participant
+// complained against a dealer who was not disqualified, the revealed share
+// replaces the share received from the dealer.
+//
+// The function returns the participant's key share, the group public key,
+// and the verification shares of all participants, including the
+// disqualified ones. The output can be passed directly to NewSigner and
+// NewCoordinator.
+func (dp *DKGParticipant) Finalize(
+	complaints []*DKGComplaint,
+	justifications []*DKGJustification,
+) (*DKGOutput, error) {
+	if dp.finalized {
+		return nil, fmt.Errorf("distributed key generation was already finalized")
+	}
+	if dp.receivedShares == nil {
+		return nil, fmt.Errorf("shares were not verified")
+	}
+
+	curve := dp.ciphersuite.Curve()
+	order := curve.Order()
+
+	type dispute struct{ dealer, complainer uint64 }
+
+	disputes := make(map[dispute]*big.Int)
+	for i, complaint := range complaints {
+		if err := dp.validateComplaint(complaint); err != nil {
+			return nil, fmt.Errorf("complaint [%d]: [%v]", i, err)
+		}
+		disputes[dispute{complaint.AccusedIndex, complaint.ComplainerIndex}] = nil
+	}
+
+	for i, justification := range justifications {
+		if justification == nil {
+			return nil, fmt.Errorf("justification [%d] is nil", i)
+		}
+
+		d := dispute{justification.DealerIndex, justification.ComplainerIndex}
+		if revealed, ok := disputes[d]; !ok || revealed != nil {
+			// Justifications of complaints nobody made and repeated
+			// justifications of the same complaint do not affect the result.
+			continue
+		}
+		if dp.verifyShare(
+			justification.DealerIndex,
+			justification.ComplainerIndex,
+			justification.SecretShare,
+		) != nil {
+			continue
+		}
+
+		disputes[d] = justification.SecretShare
+	}
+
+	disqualified := make(map[uint64]bool)
+	for d, revealed := range disputes {
+		if revealed == nil {
+			disqualified[d.dealer] = true
+		} else if d.complainer == dp.participantIndex {
+			dp.receivedShares[d.dealer] = revealed
+		}
+	}
+
+	qualified := make([]uint64, 0, dp.groupSize)
+	for l := uint64(1); l <= uint64(dp.groupSize); l++ {
+		if !disqualified[l] {
+			qualified = append(qualified, l)
+		}
+	}
+	if len(qualified) < dp.threshold {
+		return nil, fmt.Errorf(
+			"not enough qualified participants; has [%d] for threshold [%d]",
+			len(qualified),
+			dp.threshold,
+		)
+	}
+
+	// 3. Each P_i calculates their long-lived private signing share by
+	//    computing s_i = Σ f_l(i), stores s_i securely, and deletes each
+	//    f_l(i).
+	//
+	// The sum is computed over the qualified dealers only. The refresh adds
+	// the sum to the current secret key share.
+	secretKeyShare := big.NewInt(0)
+	if dp.isRefresh() {
+		secretKeyShare.Set(dp.refreshedShare)
+	}
+	for _, l := range qualified {
+		share, ok := dp.receivedShares[l]
+		if !ok {
+			return nil, fmt.Errorf(
+				"no valid secret share from qualified participant [%d]",
+				l,
+			)
+		}
+		secretKeyShare.Add(secretKeyShare, share)
+		secretKeyShare.Mod(secretKeyShare, order)
+	}
+
+	if secretKeyShare.Sign() == 0 {
+		return nil, fmt.Errorf("secret key share is zero")
+	}
+
+	// 4. Each P_i calculates their public verification share
+	//    Y_i = g^s_i, and the group's public key Y = Π φ_j0. Any participant
+	//    can compute the public verification share of any other participant
+	//    by calculating Y_i = Π_j Π_k φ_jk^(i^k mod q).
+	//
+	// The verification shares are computed from the group VSS commitment,
+	// the sum of the commitments of the qualified dealers. The refresh adds
+	// the sum to the current group VSS commitment.
+	vssCommitment := make([]*Point, dp.threshold)
+	for k := range vssCommitment {
+		vssCommitment[k] = curve.Identity()
+		if dp.isRefresh() {
+			vssCommitment[k] = dp.refreshedCommitment[k]
+		}
+		for _, l := range qualified {
+			vssCommitment[k] = curve.EcAdd(vssCommitment[k], dp.commitments[l][k])
+		}
+	}
+
+	publicKey, verificationShares, err := DeriveGroupInfo(
+		curve,
+		dp.groupSize,
+		vssCommitment,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not derive group info: [%v]", err)
+	}
+
+	if !isPointEqual(
+		curve.EcBaseMul(secretKeyShare),
+		verificationShares[dp.participantIndex],
+	) {
+		return nil, fmt.Errorf(
+			"secret key share does not match the verification share",
+		)
+	}
+
+	dp.sentShares = nil
+	dp.receivedShares = nil
+	dp.refreshedShare = nil
+	dp.finalized = true
+
+	return &DKGOutput{
+		KeyShare: &KeyShare{
+			SignerIndex:    dp.participantIndex,
+			SecretKeyShare: secretKeyShare,
+		},
+		PublicKey:             publicKey,
+		VerificationShares:    verificationShares,
+		VSSCommitment:         vssCommitment,
+		QualifiedParticipants: qualified,
+	}, nil
+}
+
+// validateSender checks the sender is a member of the group other than this
+// participant and it was not seen before.
+func (dp *DKGParticipant) validateSender(
+	sender uint64,
+	seen map[uint64][]*Point,
+) error {
+	if sender == 0 || sender > uint64(dp.groupSize) {
+		return fmt.Errorf(
+			"participant [%d] is not a member of the group of size [%d]",
+			sender,
+			dp.groupSize,
+		)
+	}
+	if sender == dp.participantIndex {
+		return fmt.Errorf("package is from the current participant")
+	}
+	if _, ok := seen[sender]; ok {
+		return fmt.Errorf("participant [%d] is duplicated", sender)
+	}
+	return nil
+}
+
+// verifyRound1Package verifies the commitment and the proof of knowledge
+// broadcast by another participant.
+func (dp *DKGParticipant) verifyRound1Package(p *DKGRound1Package) error {
+	if dp.isRefresh() {
+		return dp.verifyRefreshCommitment(p)
+	}
+
+	curve := dp.ciphersuite.Curve()
+
+	if len(p.Commitment) != dp.threshold {
+		return fmt.Errorf(
+			"commitment of participant [%d] has [%d] elements; expected [%d]",
+			p.ParticipantIndex,
+			len(p.Commitment),
+			dp.threshold,
+		)
+	}
+	if err := validateVSSCommitment(curve, p.Commitment); err != nil {
+		return fmt.Errorf(
+			"invalid commitment of participant [%d]: [%v]",
+			p.ParticipantIndex,
+			err,
+		)
+	}
+
+	proof := p.ProofOfKnowledge
+	if proof == nil || proof.R == nil || proof.R.X == nil || proof.R.Y == nil ||
+		!curve.IsPointOnCurve(proof.R) ||
+		proof.Z == nil || proof.Z.Sign() < 0 || proof.Z.Cmp(curve.Order()) >= 0 {
+		return fmt.Errorf(
+			"proof of knowledge of participant [%d] is malformed",
+			p.ParticipantIndex,
+		)
+	}
+
+	// R_l ?= g^μ_l · φ_l0^-c_l
+	c := dp.proofChallenge(p.ParticipantIndex, p.Commitment[0], proof.R)
+	expected := curve.EcSub(
+		curve.EcBaseMul(proof.Z),
+		curve.EcMul(p.Commitment[0], c),
+	)
+	if !isPointEqual(proof.R, expected) {
+		return fmt.Errorf(
+			"proof of knowledge of participant [%d] is invalid",
+			p.ParticipantIndex,
+		)
+	}
+
+	return nil
+}
+
+// verifyShare verifies the secret share of the receiver against the
+// commitment the dealer broadcast in Round 1.
+func (dp *DKGParticipant) verifyShare(
+	dealer uint64,
+	receiver uint64,
+	share *big.Int,
+) error {
+	curve := dp.ciphersuite.Curve()
+
+	if share == nil || share.Sign() < 0 || share.Cmp(curve.Order()) >= 0 {
+		return fmt.Errorf(
+			"secret share from participant [%d] is not a valid scalar",
+			dealer,
+		)
+	}
+
+	expected := evaluateVSSCommitment(curve, dp.commitments[dealer], receiver)
+	if !isPointEqual(curve.EcBaseMul(share), expected) {
+		return fmt.Errorf(
+			"secret share from participant [%d] does not match the commitment",
+			dealer,
+		)
+	}
+
+	return nil
+}
+
+// validateComplaint checks the complainer and the accused are distinct
+// members of the group.
+func (dp *DKGParticipant) validateComplaint(complaint *DKGComplaint) error {
+	if complaint == nil {
+		return fmt.Errorf("complaint is nil")
+	}
+	for _, index := range []uint64{
+		complaint.ComplainerIndex,
+		complaint.AccusedIndex,
+	} {
+		if index == 0 || index > uint64(dp.groupSize) {
+			return fmt.Errorf(
+				"participant [%d] is not a member of the group of size [%d]",
+				index,
+				dp.groupSize,
+			)
+		}
+	}
+	if complaint.ComplainerIndex == complaint.AccusedIndex {
+		return fmt.Errorf(
+			"participant [%d] complains against itself",
+			complaint.ComplainerIndex,
+		)
+	}
+	return nil
+}
+
+// proofChallenge computes c_l = H(l, Φ, φ_l0, R_l), the challenge of the
+// proof of knowledge of participant l.
+func (dp *DKGParticipant) proofChallenge(
+	participantIndex uint64,
+	publicCommitment *Point,
+	R *Point,
+) *big.Int {
+	curve := dp.ciphersuite.Curve()
+
+	// The context is prefixed with its length so that the encoding of the
+	// challenge input is unambiguous.
+	b := binary.BigEndian.AppendUint64(nil, participantIndex)
+	b = binary.BigEndian.AppendUint64(b, uint64(len(dp.context)))
+	b = append(b, dp.context...)
+
+	return dp.ciphersuite.HDKG(
+		b,
+		curve.SerializePoint(publicCommitment),
+		curve.SerializePoint(R),
+	)
+}
+
+// sortDKGError sorts culprits in ascending order, keeping causes in the same
+// order as culprits.
+func sortDKGError(dkgErr *DKGError) {
+	indexes := make([]int, len(dkgErr.Culprits))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	slices.SortFunc(indexes, func(a, b int) int {
+		switch {
+		case dkgErr.Culprits[a] < dkgErr.Culprits[b]:
+			return -1
+		case dkgErr.Culprits[a] > dkgErr.Culprits[b]:
+			return 1
+		}
+		return 0
+	})
+
+	culprits := make([]uint64, len(indexes))
+	causes := make([]error, len(indexes))
+	for i, index := range indexes {
+		culprits[i] = dkgErr.Culprits[index]
+		causes[i] = dkgErr.Causes[index]
+	}
+	dkgErr.Culprits = culprits
+	dkgErr.Causes = causes
+}
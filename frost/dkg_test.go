@@ -0,0 +1,1040 @@
+package frost
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"threshold.network/roast/internal/testutils"
+)
+
+var dkgContext = []byte("dkg session 1")
+
+func TestDKG(t *testing.T) {
+	curve := ciphersuite.Curve()
+	dkgThreshold := 7
+	dkgGroupSize := 10
+
+	participants := createDKGParticipants(t, dkgThreshold, dkgGroupSize)
+	round1Packages := executeDKGRound1(t, participants)
+	round2Packages := executeDKGRound2(t, participants, round1Packages)
+	outputs := executeDKGFinalize(t, participants, round2Packages)
+
+	first := outputs[0]
+	testutils.AssertIntsEqual(
+		t,
+		"VSS commitment length",
+		dkgThreshold,
+		len(first.VSSCommitment),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"number of verification shares",
+		dkgGroupSize,
+		len(first.VerificationShares),
+	)
+	testutils.AssertBytesEqual(
+		t,
+		curve.SerializePoint(first.PublicKey),
+		curve.SerializePoint(first.VSSCommitment[0]),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"number of qualified participants",
+		dkgGroupSize,
+		len(first.QualifiedParticipants),
+	)
+
+	// The group public key is the sum of constant term commitments of all
+	// participants.
+	publicKey := curve.Identity()
+	for _, p := range round1Packages {
+		publicKey = curve.EcAdd(publicKey, p.Commitment[0])
+	}
+	testutils.AssertBytesEqual(
+		t,
+		curve.SerializePoint(publicKey),
+		curve.SerializePoint(first.PublicKey),
+	)
+
+	for i, output := range outputs {
+		testutils.AssertUintsEqual(
+			t,
+			"signer index",
+			uint64(i+1),
+			output.KeyShare.SignerIndex,
+		)
+
+		if !VerifyKeyShare(curve, output.KeyShare, output.VSSCommitment) {
+			t.Errorf(
+				"key share of participant [%d] is not valid",
+				output.KeyShare.SignerIndex,
+			)
+		}
+
+		// All participants agree on the public output.
+		testutils.AssertBytesEqual(
+			t,
+			curve.SerializePoint(first.PublicKey),
+			curve.SerializePoint(output.PublicKey),
+		)
+		for k := range first.VSSCommitment {
+			testutils.AssertBytesEqual(
+				t,
+				curve.SerializePoint(first.VSSCommitment[k]),
+				curve.SerializePoint(output.VSSCommitment[k]),
+			)
+		}
+		for j, verificationShare := range first.VerificationShares {
+			testutils.AssertBytesEqual(
+				t,
+				curve.SerializePoint(verificationShare),
+				curve.SerializePoint(output.VerificationShares[j]),
+			)
+		}
+	}
+
+	// Any threshold of shares interpolates to the secret key of the group
+	// public key.
+	participant := &Participant{ciphersuite: ciphersuite}
+	L := []uint64{2, 3, 5, 6, 7, 8, 10}
+	secret := big.NewInt(0)
+	for _, i := range L {
+		term := new(big.Int).Mul(
+			participant.deriveInterpolatingValue(i, L),
+			outputs[i-1].KeyShare.SecretKeyShare,
+		)
+		secret.Add(secret, term)
+	}
+	secret.Mod(secret, curve.Order())
+	testutils.AssertBytesEqual(
+		t,
+		curve.SerializePoint(first.PublicKey),
+		curve.SerializePoint(curve.EcBaseMul(secret)),
+	)
+}
+
+func TestDKG_Signing(t *testing.T) {
+	message := []byte("All we have to decide is what to do with the time")
+	dkgThreshold := 3
+	dkgGroupSize := 5
+
+	participants := createDKGParticipants(t, dkgThreshold, dkgGroupSize)
+	round1Packages := executeDKGRound1(t, participants)
+	round2Packages := executeDKGRound2(t, participants, round1Packages)
+	outputs := executeDKGFinalize(t, participants, round2Packages)
+
+	signers := make([]*Signer, dkgThreshold)
+	for i := range signers {
+		// The last threshold of members takes part in the signing.
+		output := outputs[dkgGroupSize-dkgThreshold+i]
+		signers[i] = NewSigner(
+			ciphersuite,
+			output.KeyShare.SignerIndex,
+			output.PublicKey,
+			dkgThreshold,
+			dkgGroupSize,
+			output.KeyShare.SecretKeyShare,
+		)
+	}
+
+	coordinator := NewCoordinator(
+		ciphersuite,
+		outputs[0].PublicKey,
+		dkgThreshold,
+		dkgGroupSize,
+		outputs[0].VerificationShares,
+	)
+
+	commitments := executeRound1(t, signers)
+	signatureShares := executeRound2(t, signers, message, commitments)
+
+	signature, err := coordinator.AggregateIdentifiable(
+		message,
+		commitments,
+		signatureShares,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid, err := ciphersuite.VerifySignature(
+		signature,
+		outputs[0].PublicKey,
+		message,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "signature validity", true, valid)
+}
+
+func TestNewDKGParticipant_Failures(t *testing.T) {
+	tests := map[string]struct {
+		participantIndex uint64
+		threshold        int
+		groupSize        int
+		expectedErr      string
+	}{
+		"threshold lower than 2": {
+			participantIndex: 1,
+			threshold:        1,
+			groupSize:        3,
+			expectedErr:      "threshold must be at least [2]; has [1]",
+		},
+		"threshold greater than the group size": {
+			participantIndex: 1,
+			threshold:        4,
+			groupSize:        3,
+			expectedErr:      "threshold [4] is greater than the group size [3]",
+		},
+		"zero participant identifier": {
+			participantIndex: 0,
+			threshold:        2,
+			groupSize:        3,
+			expectedErr:      "participant [0] is not a member of the group of size [3]",
+		},
+		"participant identifier greater than the group size": {
+			participantIndex: 4,
+			threshold:        2,
+			groupSize:        3,
+			expectedErr:      "participant [4] is not a member of the group of size [3]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, err := NewDKGParticipant(
+				ciphersuite,
+				test.participantIndex,
+				test.threshold,
+				test.groupSize,
+				dkgContext,
+			)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			testutils.AssertStringsEqual(
+				t,
+				"participant error",
+				test.expectedErr,
+				err.Error(),
+			)
+		})
+	}
+}
+
+func TestDKGRound2_Failures(t *testing.T) {
+	curve := ciphersuite.Curve()
+
+	tests := map[string]struct {
+		modify           func([]*DKGRound1Package) []*DKGRound1Package
+		expectedErr      string
+		expectedCulprits []uint64
+	}{
+		"missing package": {
+			modify: func(p []*DKGRound1Package) []*DKGRound1Package {
+				return p[:3]
+			},
+			expectedErr: "expected round one packages of [4] other participants; has [3]",
+		},
+		"nil package": {
+			modify: func(p []*DKGRound1Package) []*DKGRound1Package {
+				p[1] = nil
+				return p
+			},
+			expectedErr: "round one package [1] is nil",
+		},
+		"duplicated participant": {
+			modify: func(p []*DKGRound1Package) []*DKGRound1Package {
+				p[2] = p[1]
+				return p
+			},
+			expectedErr: "round one package [2]: [participant [3] is duplicated]",
+		},
+		"package from the current participant": {
+			modify: func(p []*DKGRound1Package) []*DKGRound1Package {
+				p[0] = &DKGRound1Package{ParticipantIndex: 1}
+				return p
+			},
+			expectedErr: "round one package [0]: [package is from the current participant]",
+		},
+		"participant not a member of the group": {
+			modify: func(p []*DKGRound1Package) []*DKGRound1Package {
+				p[3] = &DKGRound1Package{ParticipantIndex: 6}
+				return p
+			},
+			expectedErr: "round one package [3]: [participant [6] is not a member " +
+				"of the group of size [5]]",
+		},
+		"commitment of wrong length": {
+			modify: func(p []*DKGRound1Package) []*DKGRound1Package {
+				p[0].Commitment = p[0].Commitment[:2]
+				return p
+			},
+			expectedErr: "distributed key generation failed; participants [2] " +
+				"sent invalid packages: [commitment of participant [2] has [2] " +
+				"elements; expected [3]]",
+			expectedCulprits: []uint64{2},
+		},
+		"commitment with the identity element": {
+			modify: func(p []*DKGRound1Package) []*DKGRound1Package {
+				p[1].Commitment[2] = curve.Identity()
+				return p
+			},
+			expectedErr: "distributed key generation failed; participants [3] " +
+				"sent invalid packages: [invalid commitment of participant [3]: " +
+				"[VSS commitment element [2] is not a valid non-identity point " +
+				"on the curve]]",
+			expectedCulprits: []uint64{3},
+		},
+		"missing proof of knowledge": {
+			modify: func(p []*DKGRound1Package) []*DKGRound1Package {
+				p[2].ProofOfKnowledge = nil
+				return p
+			},
+			expectedErr: "distributed key generation failed; participants [4] " +
+				"sent invalid packages: [proof of knowledge of participant [4] " +
+				"is malformed]",
+			expectedCulprits: []uint64{4},
+		},
+		"tampered proofs of knowledge": {
+			modify: func(p []*DKGRound1Package) []*DKGRound1Package {
+				// The order of packages does not affect the order of culprits.
+				p[3].ProofOfKnowledge.Z = new(big.Int).Add(
+					p[3].ProofOfKnowledge.Z,
+					big.NewInt(1),
+				)
+				p[0].ProofOfKnowledge.R = curve.EcBaseMul(big.NewInt(7))
+				return p
+			},
+			expectedErr: "distributed key generation failed; participants [2 5] " +
+				"sent invalid packages: [proof of knowledge of participant [2] " +
+				"is invalid\nproof of knowledge of participant [5] is invalid]",
+			expectedCulprits: []uint64{2, 5},
+		},
+		"rogue key": {
+			modify: func(p []*DKGRound1Package) []*DKGRound1Package {
+				// The participant replaces its constant term commitment with
+				// one it does not know the discrete logarithm of, keeping the
+				// original proof.
+				p[1].Commitment[0] = curve.EcSub(
+					p[1].Commitment[0],
+					p[0].Commitment[0],
+				)
+				return p
+			},
+			expectedErr: "distributed key generation failed; participants [3] " +
+				"sent invalid packages: [proof of knowledge of participant [3] " +
+				"is invalid]",
+			expectedCulprits: []uint64{3},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			participants := createDKGParticipants(t, 3, 5)
+			round1Packages := executeDKGRound1(t, participants)
+
+			// Packages of other participants, as received by the first one.
+			_, err := participants[0].Round2(
+				test.modify(cloneDKGRound1Packages(round1Packages[1:])),
+			)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			testutils.AssertStringsEqual(
+				t,
+				"round two error",
+				test.expectedErr,
+				err.Error(),
+			)
+
+			var dkgErr *DKGError
+			if test.expectedCulprits == nil {
+				testutils.AssertBoolsEqual(
+					t,
+					"is DKG error",
+					false,
+					errors.As(err, &dkgErr),
+				)
+				return
+			}
+			if !errors.As(err, &dkgErr) {
+				t.Fatalf("expected DKG error; has [%T]", err)
+			}
+			assertCulpritsEqual(t, test.expectedCulprits, dkgErr.Culprits)
+		})
+	}
+}
+
+func TestDKGRound2_WrongContext(t *testing.T) {
+	participants := createDKGParticipants(t, 2, 3)
+
+	// The third participant generates its proof of knowledge in another
+	// session. The proof can not be replayed in this one.
+	other, err := NewDKGParticipant(ciphersuite, 3, 2, 3, []byte("dkg session 2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	participants[2] = other
+
+	round1Packages := executeDKGRound1(t, participants)
+
+	_, err = participants[0].Round2(round1Packages[1:])
+	var dkgErr *DKGError
+	if !errors.As(err, &dkgErr) {
+		t.Fatalf("expected DKG error; has [%v]", err)
+	}
+	assertCulpritsEqual(t, []uint64{3}, dkgErr.Culprits)
+}
+
+func TestDKGVerifyShares(t *testing.T) {
+	tests := map[string]struct {
+		modify             func([]*DKGRound2Package) []*DKGRound2Package
+		expectedComplaints []uint64
+	}{
+		"all shares valid": {
+			modify: func(p []*DKGRound2Package) []*DKGRound2Package {
+				return p
+			},
+			expectedComplaints: []uint64{},
+		},
+		"missing shares": {
+			modify: func(p []*DKGRound2Package) []*DKGRound2Package {
+				return p[1:3]
+			},
+			expectedComplaints: []uint64{2, 5},
+		},
+		"nil secret share": {
+			modify: func(p []*DKGRound2Package) []*DKGRound2Package {
+				p[1].SecretShare = nil
+				return p
+			},
+			expectedComplaints: []uint64{3},
+		},
+		"tampered secret shares": {
+			modify: func(p []*DKGRound2Package) []*DKGRound2Package {
+				p[3].SecretShare = new(big.Int).Add(p[3].SecretShare, big.NewInt(1))
+				p[0].SecretShare = new(big.Int).Sub(p[0].SecretShare, big.NewInt(1))
+				return p
+			},
+			expectedComplaints: []uint64{2, 5},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			participants := createDKGParticipants(t, 3, 5)
+			round1Packages := executeDKGRound1(t, participants)
+			round2Packages := executeDKGRound2(t, participants, round1Packages)
+
+			// Packages sent to the first participant.
+			complaints, err := participants[0].VerifyShares(
+				test.modify(cloneDKGRound2Packages(
+					receivedDKGRound2Packages(round2Packages, 1),
+				)),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"number of complaints",
+				len(test.expectedComplaints),
+				len(complaints),
+			)
+			for i, complaint := range complaints {
+				testutils.AssertUintsEqual(
+					t,
+					"complainer",
+					1,
+					complaint.ComplainerIndex,
+				)
+				testutils.AssertUintsEqual(
+					t,
+					"accused",
+					test.expectedComplaints[i],
+					complaint.AccusedIndex,
+				)
+			}
+		})
+	}
+}
+
+func TestDKGVerifyShares_Failures(t *testing.T) {
+	tests := map[string]struct {
+		modify      func([]*DKGRound2Package) []*DKGRound2Package
+		expectedErr string
+	}{
+		"too many packages": {
+			modify: func(p []*DKGRound2Package) []*DKGRound2Package {
+				return append(p, p[0])
+			},
+			expectedErr: "expected at most round two packages of [4] other " +
+				"participants; has [5]",
+		},
+		"nil package": {
+			modify: func(p []*DKGRound2Package) []*DKGRound2Package {
+				p[2] = nil
+				return p
+			},
+			expectedErr: "round two package [2] is nil",
+		},
+		"duplicated sender": {
+			modify: func(p []*DKGRound2Package) []*DKGRound2Package {
+				p[3] = p[0]
+				return p
+			},
+			expectedErr: "round two package [3]: [participant [2] is duplicated]",
+		},
+		"package for another participant": {
+			modify: func(p []*DKGRound2Package) []*DKGRound2Package {
+				p[1].ReceiverIndex = 4
+				return p
+			},
+			expectedErr: "round two package [1] is for participant [4]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			participants := createDKGParticipants(t, 3, 5)
+			round1Packages := executeDKGRound1(t, participants)
+			round2Packages := executeDKGRound2(t, participants, round1Packages)
+
+			// Packages sent to the first participant.
+			_, err := participants[0].VerifyShares(
+				test.modify(cloneDKGRound2Packages(
+					receivedDKGRound2Packages(round2Packages, 1),
+				)),
+			)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			testutils.AssertStringsEqual(
+				t,
+				"verify shares error",
+				test.expectedErr,
+				err.Error(),
+			)
+		})
+	}
+}
+
+func TestDKG_Complaints(t *testing.T) {
+	curve := ciphersuite.Curve()
+
+	tamper := func(p [][]*DKGRound2Package, sender uint64, receiver uint64) {
+		for _, pp := range p[sender-1] {
+			if pp.ReceiverIndex == receiver {
+				pp.SecretShare = new(big.Int).Add(pp.SecretShare, big.NewInt(1))
+			}
+		}
+	}
+	drop := func(p [][]*DKGRound2Package, sender uint64, receiver uint64) {
+		p[sender-1] = slices.DeleteFunc(p[sender-1], func(pp *DKGRound2Package) bool {
+			return pp.ReceiverIndex == receiver
+		})
+	}
+
+	tests := map[string]struct {
+		modifyShares         func([][]*DKGRound2Package)
+		modifyComplaints     func([]*DKGComplaint) []*DKGComplaint
+		modifyJustifications func([]*DKGJustification) []*DKGJustification
+		expectedComplaints   int
+		expectedQualified    []uint64
+	}{
+		"dealer reveals valid shares": {
+			modifyShares: func(p [][]*DKGRound2Package) {
+				tamper(p, 2, 1)
+				tamper(p, 2, 3)
+			},
+			expectedComplaints: 2,
+			expectedQualified:  []uint64{1, 2, 3, 4, 5},
+		},
+		"dealer reveals a missing share": {
+			modifyShares: func(p [][]*DKGRound2Package) {
+				drop(p, 5, 2)
+			},
+			expectedComplaints: 1,
+			expectedQualified:  []uint64{1, 2, 3, 4, 5},
+		},
+		"false complaint against honest dealer": {
+			modifyComplaints: func(c []*DKGComplaint) []*DKGComplaint {
+				return append(c, &DKGComplaint{ComplainerIndex: 3, AccusedIndex: 2})
+			},
+			expectedComplaints: 1,
+			expectedQualified:  []uint64{1, 2, 3, 4, 5},
+		},
+		"dealer does not answer complaint": {
+			modifyShares: func(p [][]*DKGRound2Package) {
+				tamper(p, 4, 1)
+			},
+			modifyJustifications: func(j []*DKGJustification) []*DKGJustification {
+				return slices.DeleteFunc(j, func(jj *DKGJustification) bool {
+					return jj.DealerIndex == 4
+				})
+			},
+			expectedComplaints: 1,
+			expectedQualified:  []uint64{1, 2, 3, 5},
+		},
+		"dealer reveals invalid share": {
+			modifyShares: func(p [][]*DKGRound2Package) {
+				tamper(p, 4, 1)
+				tamper(p, 4, 2)
+			},
+			modifyJustifications: func(j []*DKGJustification) []*DKGJustification {
+				for _, jj := range j {
+					if jj.DealerIndex == 4 && jj.ComplainerIndex == 2 {
+						jj.SecretShare = new(big.Int).Add(
+							jj.SecretShare,
+							big.NewInt(1),
+						)
+					}
+				}
+				return j
+			},
+			expectedComplaints: 2,
+			expectedQualified:  []uint64{1, 2, 3, 5},
+		},
+		"two dealers disqualified": {
+			modifyShares: func(p [][]*DKGRound2Package) {
+				drop(p, 1, 5)
+				tamper(p, 3, 4)
+			},
+			modifyJustifications: func(j []*DKGJustification) []*DKGJustification {
+				return nil
+			},
+			expectedComplaints: 2,
+			expectedQualified:  []uint64{2, 4, 5},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			dkgThreshold := 3
+			dkgGroupSize := 5
+
+			participants := createDKGParticipants(t, dkgThreshold, dkgGroupSize)
+			round1Packages := executeDKGRound1(t, participants)
+			round2Packages := executeDKGRound2(t, participants, round1Packages)
+
+			if test.modifyShares != nil {
+				test.modifyShares(round2Packages)
+			}
+
+			complaints := executeDKGVerifyShares(t, participants, round2Packages)
+			if test.modifyComplaints != nil {
+				complaints = test.modifyComplaints(complaints)
+			}
+			testutils.AssertIntsEqual(
+				t,
+				"number of complaints",
+				test.expectedComplaints,
+				len(complaints),
+			)
+
+			justifications := executeDKGJustify(t, participants, complaints)
+			if test.modifyJustifications != nil {
+				justifications = test.modifyJustifications(justifications)
+			}
+
+			outputs := make([]*DKGOutput, len(participants))
+			for i, participant := range participants {
+				output, err := participant.Finalize(complaints, justifications)
+				if err != nil {
+					t.Fatal(err)
+				}
+				outputs[i] = output
+			}
+
+			// The group public key is the sum of constant term commitments of
+			// the qualified participants.
+			publicKey := curve.Identity()
+			for _, l := range test.expectedQualified {
+				publicKey = curve.EcAdd(publicKey, round1Packages[l-1].Commitment[0])
+			}
+
+			for _, output := range outputs {
+				testutils.AssertIntsEqual(
+					t,
+					"number of qualified participants",
+					len(test.expectedQualified),
+					len(output.QualifiedParticipants),
+				)
+				for i := range test.expectedQualified {
+					testutils.AssertUintsEqual(
+						t,
+						"qualified participant",
+						test.expectedQualified[i],
+						output.QualifiedParticipants[i],
+					)
+				}
+
+				testutils.AssertBytesEqual(
+					t,
+					curve.SerializePoint(publicKey),
+					curve.SerializePoint(output.PublicKey),
+				)
+				for j, verificationShare := range outputs[0].VerificationShares {
+					testutils.AssertBytesEqual(
+						t,
+						curve.SerializePoint(verificationShare),
+						curve.SerializePoint(output.VerificationShares[j]),
+					)
+				}
+
+				if !VerifyKeyShare(curve, output.KeyShare, output.VSSCommitment) {
+					t.Errorf(
+						"key share of participant [%d] is not valid",
+						output.KeyShare.SignerIndex,
+					)
+				}
+			}
+
+			// Any threshold of shares, including the shares of disqualified
+			// participants, interpolates to the secret key of the group public
+			// key.
+			participant := &Participant{ciphersuite: ciphersuite}
+			L := []uint64{1, 3, 4}
+			secret := big.NewInt(0)
+			for _, i := range L {
+				term := new(big.Int).Mul(
+					participant.deriveInterpolatingValue(i, L),
+					outputs[i-1].KeyShare.SecretKeyShare,
+				)
+				secret.Add(secret, term)
+			}
+			secret.Mod(secret, curve.Order())
+			testutils.AssertBytesEqual(
+				t,
+				curve.SerializePoint(publicKey),
+				curve.SerializePoint(curve.EcBaseMul(secret)),
+			)
+		})
+	}
+}
+
+func TestDKGJustify(t *testing.T) {
+	participants := createDKGParticipants(t, 2, 3)
+	round1Packages := executeDKGRound1(t, participants)
+	round2Packages := executeDKGRound2(t, participants, round1Packages)
+	executeDKGVerifyShares(t, participants, round2Packages)
+
+	justifications, err := participants[1].Justify([]*DKGComplaint{
+		{ComplainerIndex: 1, AccusedIndex: 2},
+		{ComplainerIndex: 1, AccusedIndex: 3},
+		{ComplainerIndex: 1, AccusedIndex: 2},
+		{ComplainerIndex: 3, AccusedIndex: 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Repeated complaints are answered once and complaints against other
+	// participants are ignored.
+	testutils.AssertIntsEqual(t, "number of justifications", 2, len(justifications))
+	sent := round2Packages[1]
+	for i, complainer := range []uint64{1, 3} {
+		justification := justifications[i]
+		index := slices.IndexFunc(sent, func(p *DKGRound2Package) bool {
+			return p.ReceiverIndex == complainer
+		})
+		testutils.AssertUintsEqual(t, "dealer", 2, justification.DealerIndex)
+		testutils.AssertUintsEqual(
+			t,
+			"complainer",
+			complainer,
+			justification.ComplainerIndex,
+		)
+		testutils.AssertBigIntsEqual(
+			t,
+			"revealed share",
+			sent[index].SecretShare,
+			justification.SecretShare,
+		)
+	}
+}
+
+func TestDKGFinalize_Failures(t *testing.T) {
+	tests := map[string]struct {
+		complaints     []*DKGComplaint
+		justifications []*DKGJustification
+		expectedErr    string
+	}{
+		"nil complaint": {
+			complaints:  []*DKGComplaint{nil},
+			expectedErr: "complaint [0]: [complaint is nil]",
+		},
+		"complainer not a member of the group": {
+			complaints: []*DKGComplaint{
+				{ComplainerIndex: 1, AccusedIndex: 2},
+				{ComplainerIndex: 6, AccusedIndex: 2},
+			},
+			expectedErr: "complaint [1]: [participant [6] is not a member of " +
+				"the group of size [5]]",
+		},
+		"accused not a member of the group": {
+			complaints: []*DKGComplaint{
+				{ComplainerIndex: 1, AccusedIndex: 0},
+			},
+			expectedErr: "complaint [0]: [participant [0] is not a member of " +
+				"the group of size [5]]",
+		},
+		"complaint against itself": {
+			complaints: []*DKGComplaint{
+				{ComplainerIndex: 3, AccusedIndex: 3},
+			},
+			expectedErr: "complaint [0]: [participant [3] complains against itself]",
+		},
+		"nil justification": {
+			justifications: []*DKGJustification{nil},
+			expectedErr:    "justification [0] is nil",
+		},
+		"not enough qualified participants": {
+			complaints: []*DKGComplaint{
+				{ComplainerIndex: 1, AccusedIndex: 2},
+				{ComplainerIndex: 1, AccusedIndex: 3},
+				{ComplainerIndex: 2, AccusedIndex: 4},
+			},
+			expectedErr: "not enough qualified participants; has [2] for " +
+				"threshold [3]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			participants := createDKGParticipants(t, 3, 5)
+			round1Packages := executeDKGRound1(t, participants)
+			round2Packages := executeDKGRound2(t, participants, round1Packages)
+			executeDKGVerifyShares(t, participants, round2Packages)
+
+			_, err := participants[0].Finalize(test.complaints, test.justifications)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			testutils.AssertStringsEqual(
+				t,
+				"finalize error",
+				test.expectedErr,
+				err.Error(),
+			)
+		})
+	}
+}
+
+func TestDKG_RoundOrder(t *testing.T) {
+	participants := createDKGParticipants(t, 2, 3)
+	participant := participants[0]
+
+	assertErr := func(name string, expected string, err error) {
+		if err == nil {
+			t.Fatalf("expected %s error", name)
+		}
+		testutils.AssertStringsEqual(t, name+" error", expected, err.Error())
+	}
+
+	_, err := participant.Round2(nil)
+	assertErr("round two", "round one was not executed", err)
+	_, err = participant.VerifyShares(nil)
+	assertErr("verify shares", "round two was not executed", err)
+	_, err = participant.Justify(nil)
+	assertErr("justify", "shares were not verified", err)
+	_, err = participant.Finalize(nil, nil)
+	assertErr("finalize", "shares were not verified", err)
+
+	round1Packages := executeDKGRound1(t, participants)
+	_, err = participant.Round1()
+	assertErr("round one", "round one was already executed", err)
+
+	round2Packages := executeDKGRound2(t, participants, round1Packages)
+	_, err = participant.Round2(round1Packages[1:])
+	assertErr("round two", "round two was already executed", err)
+
+	complaints := executeDKGVerifyShares(t, participants, round2Packages)
+	_, err = participant.VerifyShares(receivedDKGRound2Packages(round2Packages, 1))
+	assertErr("verify shares", "shares were already verified", err)
+
+	justifications := executeDKGJustify(t, participants, complaints)
+	finalizeDKG(t, participants, complaints, justifications)
+	_, err = participant.Finalize(complaints, justifications)
+	assertErr("finalize", "distributed key generation was already finalized", err)
+}
+
+func createDKGParticipants(
+	t *testing.T,
+	threshold int,
+	groupSize int,
+) []*DKGParticipant {
+	participants := make([]*DKGParticipant, groupSize)
+	for i := range participants {
+		participant, err := NewDKGParticipant(
+			ciphersuite,
+			uint64(i+1),
+			threshold,
+			groupSize,
+			dkgContext,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		participants[i] = participant
+	}
+	return participants
+}
+
+func executeDKGRound1(
+	t *testing.T,
+	participants []*DKGParticipant,
+) []*DKGRound1Package {
+	packages := make([]*DKGRound1Package, len(participants))
+	for i, participant := range participants {
+		p, err := participant.Round1()
+		if err != nil {
+			t.Fatal(err)
+		}
+		packages[i] = p
+	}
+	return packages
+}
+
+// executeDKGRound2 executes Round 2 for all participants and returns the
+// packages grouped by the sender, as packages[sender index - 1].
+func executeDKGRound2(
+	t *testing.T,
+	participants []*DKGParticipant,
+	round1Packages []*DKGRound1Package,
+) [][]*DKGRound2Package {
+	packages := make([][]*DKGRound2Package, len(participants))
+	for i, participant := range participants {
+		received := make([]*DKGRound1Package, 0, len(round1Packages)-1)
+		for j, p := range round1Packages {
+			if i != j {
+				received = append(received, p)
+			}
+		}
+
+		p, err := participant.Round2(received)
+		if err != nil {
+			t.Fatal(err)
+		}
+		packages[i] = p
+	}
+	return packages
+}
+
+// executeDKGFinalize executes the share verification, the complaint phase,
+// and the finalization for all participants.
+func executeDKGFinalize(
+	t *testing.T,
+	participants []*DKGParticipant,
+	round2Packages [][]*DKGRound2Package,
+) []*DKGOutput {
+	complaints := executeDKGVerifyShares(t, participants, round2Packages)
+	justifications := executeDKGJustify(t, participants, complaints)
+	return finalizeDKG(t, participants, complaints, justifications)
+}
+
+// executeDKGVerifyShares executes VerifyShares for all participants and
+// returns the complaints of all of them.
+func executeDKGVerifyShares(
+	t *testing.T,
+	participants []*DKGParticipant,
+	round2Packages [][]*DKGRound2Package,
+) []*DKGComplaint {
+	complaints := make([]*DKGComplaint, 0)
+	for _, participant := range participants {
+		c, err := participant.VerifyShares(
+			receivedDKGRound2Packages(round2Packages, participant.participantIndex),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		complaints = append(complaints, c...)
+	}
+	return complaints
+}
+
+// executeDKGJustify executes Justify for all participants and returns the
+// justifications of all of them.
+func executeDKGJustify(
+	t *testing.T,
+	participants []*DKGParticipant,
+	complaints []*DKGComplaint,
+) []*DKGJustification {
+	justifications := make([]*DKGJustification, 0)
+	for _, participant := range participants {
+		j, err := participant.Justify(complaints)
+		if err != nil {
+			t.Fatal(err)
+		}
+		justifications = append(justifications, j...)
+	}
+	return justifications
+}
+
+func finalizeDKG(
+	t *testing.T,
+	participants []*DKGParticipant,
+	complaints []*DKGComplaint,
+	justifications []*DKGJustification,
+) []*DKGOutput {
+	outputs := make([]*DKGOutput, len(participants))
+	for i, participant := range participants {
+		output, err := participant.Finalize(complaints, justifications)
+		if err != nil {
+			t.Fatal(err)
+		}
+		outputs[i] = output
+	}
+	return outputs
+}
+
+// receivedDKGRound2Packages returns the packages sent to the receiver from
+// packages grouped by the sender.
+func receivedDKGRound2Packages(
+	round2Packages [][]*DKGRound2Package,
+	receiver uint64,
+) []*DKGRound2Package {
+	received := make([]*DKGRound2Package, 0, len(round2Packages)-1)
+	for _, sent := range round2Packages {
+		for _, p := range sent {
+			if p.ReceiverIndex == receiver {
+				received = append(received, p)
+			}
+		}
+	}
+	return received
+}
+
+func cloneDKGRound1Packages(packages []*DKGRound1Package) []*DKGRound1Package {
+	cloned := make([]*DKGRound1Package, len(packages))
+	for i, p := range packages {
+		proof := *p.ProofOfKnowledge
+		cloned[i] = &DKGRound1Package{
+			ParticipantIndex: p.ParticipantIndex,
+			Commitment:       append([]*Point{}, p.Commitment...),
+			ProofOfKnowledge: &proof,
+		}
+	}
+	return cloned
+}
+
+func cloneDKGRound2Packages(packages []*DKGRound2Package) []*DKGRound2Package {
+	cloned := make([]*DKGRound2Package, len(packages))
+	for i, p := range packages {
+		c := *p
+		cloned[i] = &c
+	}
+	return cloned
+}
+
+func assertCulpritsEqual(t *testing.T, expected []uint64, actual []uint64) {
+	testutils.AssertIntsEqual(t, "number of culprits", len(expected), len(actual))
+	for i := range expected {
+		testutils.AssertUintsEqual(t, "culprit", expected[i], actual[i])
+	}
+}
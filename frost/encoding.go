@@ -0,0 +1,455 @@
+package frost
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// EncodingVersion is the version of the binary wire format of [FROST]
+// protocol messages. Every encoded message starts with the version byte
+// followed by the message type byte so that a message of an unknown version
+// or of another type is never decoded.
+//
+// Points are encoded with Curve.SerializePoint and decoded with
+// Curve.DeserializePoint. Scalars and signer identifiers are encoded as
+// fixed-length big-endian integers, the same way they are encoded in the
+// group commitment list in [FROST] section 4.3. List Operations.
+const EncodingVersion = byte(0x01)
+
+const (
+	nonceCommitmentMessage = byte(0x01)
+	signatureShareMessage  = byte(0x02)
+	signatureMessage       = byte(0x03)
+	signingPackageMessage  = byte(0x04)
+)
+
+// signerIndexLength is the byte length of the encoded signer identifier.
+const signerIndexLength = 8
+
+// SigningPackage is the message sent by the coordinator to every signer in
+// [FROST] section 5.2. Round Two - Signature Share Generation. It holds the
+// message to be signed and the commitment list of all signers taking part in
+// the signing, sorted by the signer identifier.
+type SigningPackage struct {
+	Message     []byte
+	Commitments []*NonceCommitment
+}
+
+// EncodeNonceCommitment encodes the nonce commitment produced in Round One:
+//
+//	version || type || ser64(identifier) ||
+//	  SerializePoint(hiding_nonce_commitment) ||
+//	  SerializePoint(binding_nonce_commitment)
+func EncodeNonceCommitment(curve Curve, commitment *NonceCommitment) ([]byte, error) {
+	b := encodeHeader(nonceCommitmentMessage)
+	return appendNonceCommitment(curve, b, commitment)
+}
+
+// DecodeNonceCommitment decodes the nonce commitment encoded with
+// EncodeNonceCommitment. The function returns an error if any of the
+// commitment points is not a valid, non-identity point on the curve, if the
+// signer identifier is zero, or if there are any bytes left after the
+// commitment.
+func DecodeNonceCommitment(curve Curve, b []byte) (*NonceCommitment, error) {
+	d, err := newDecoder(curve, b, nonceCommitmentMessage)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode nonce commitment: [%v]", err)
+	}
+
+	commitment, err := d.nonceCommitment()
+	if err == nil {
+		err = d.finish()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not decode nonce commitment: [%v]", err)
+	}
+
+	return commitment, nil
+}
+
+// EncodeSignatureShare encodes the signature share produced in Round Two:
+//
+//	version || type || SerializeScalar(sig_share)
+func EncodeSignatureShare(curve Curve, signatureShare *big.Int) ([]byte, error) {
+	b := encodeHeader(signatureShareMessage)
+	return appendScalar(curve, b, signatureShare)
+}
+
+// DecodeSignatureShare decodes the signature share encoded with
+// EncodeSignatureShare. The function returns an error if the share is not
+// lower than the group order or if there are any bytes left after the share.
+func DecodeSignatureShare(curve Curve, b []byte) (*big.Int, error) {
+	d, err := newDecoder(curve, b, signatureShareMessage)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode signature share: [%v]", err)
+	}
+
+	signatureShare, err := d.scalar()
+	if err == nil {
+		err = d.finish()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not decode signature share: [%v]", err)
+	}
+
+	return signatureShare, nil
+}
+
+// EncodeSignature encodes the aggregated signature:
+//
+//	version || type || SerializePoint(R) || SerializeScalar(z)
+//
+// Note that this is the internal encoding of the signature, not the 64-byte
+// signature defined by [BIP-340].
+func EncodeSignature(curve Curve, signature *Signature) ([]byte, error) {
+	if signature == nil {
+		return nil, fmt.Errorf("signature is nil")
+	}
+
+	b := encodeHeader(signatureMessage)
+	b, err := appendPoint(curve, b, signature.R)
+	if err != nil {
+		return nil, fmt.Errorf("invalid R: [%v]", err)
+	}
+	b, err = appendScalar(curve, b, signature.Z)
+	if err != nil {
+		return nil, fmt.Errorf("invalid z: [%v]", err)
+	}
+
+	return b, nil
+}
+
+// DecodeSignature decodes the signature encoded with EncodeSignature. The
+// function returns an error if R is not a valid, non-identity point on the
+// curve, if z is not lower than the group order, or if there are any bytes
+// left after the signature. The function does not verify the signature.
+func DecodeSignature(curve Curve, b []byte) (*Signature, error) {
+	d, err := newDecoder(curve, b, signatureMessage)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode signature: [%v]", err)
+	}
+
+	R, err := d.point()
+	if err != nil {
+		return nil, fmt.Errorf("could not decode signature R: [%v]", err)
+	}
+	z, err := d.scalar()
+	if err != nil {
+		return nil, fmt.Errorf("could not decode signature z: [%v]", err)
+	}
+	if err := d.finish(); err != nil {
+		return nil, fmt.Errorf("could not decode signature: [%v]", err)
+	}
+
+	return &Signature{R: R, Z: z}, nil
+}
+
+// EncodeSigningPackage encodes the signing package:
+//
+//	version || type || ser32(len(msg)) || msg ||
+//	  ser32(len(commitment_list)) || encoded_commitment_1 || ... ||
+//	  encoded_commitment_n
+//
+// where every encoded commitment is the nonce commitment encoding without the
+// version and type bytes.
+func EncodeSigningPackage(curve Curve, signingPackage *SigningPackage) ([]byte, error) {
+	if signingPackage == nil {
+		return nil, fmt.Errorf("signing package is nil")
+	}
+
+	if uint64(len(signingPackage.Message)) > math.MaxUint32 {
+		return nil, fmt.Errorf(
+			"message has [%d] bytes; the maximum is [%d]",
+			len(signingPackage.Message),
+			uint32(math.MaxUint32),
+		)
+	}
+
+	b := encodeHeader(signingPackageMessage)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(signingPackage.Message)))
+	b = append(b, signingPackage.Message...)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(signingPackage.Commitments)))
+
+	var err error
+	for i, commitment := range signingPackage.Commitments {
+		b, err = appendNonceCommitment(curve, b, commitment)
+		if err != nil {
+			return nil, fmt.Errorf("commitment [%d]: [%v]", i, err)
+		}
+		if i > 0 && !isSortedAfter(signingPackage.Commitments[i-1], commitment) {
+			return nil, fmt.Errorf(
+				"commitment [%d] is not sorted in ascending order of signer "+
+					"identifiers or is duplicated",
+				i,
+			)
+		}
+	}
+
+	return b, nil
+}
+
+// DecodeSigningPackage decodes the signing package encoded with
+// EncodeSigningPackage. Every commitment is validated the same way
+// DecodeNonceCommitment validates it. Additionally, the commitments must be
+// sorted in ascending order of the signer identifier with no duplicates, as
+// [FROST] requires for the commitment list. The function returns an error if
+// there are any bytes left after the last commitment.
+func DecodeSigningPackage(curve Curve, b []byte) (*SigningPackage, error) {
+	d, err := newDecoder(curve, b, signingPackageMessage)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode signing package: [%v]", err)
+	}
+
+	messageLength, err := d.uint32()
+	if err != nil {
+		return nil, fmt.Errorf("could not decode message length: [%v]", err)
+	}
+	message, err := d.next(int(messageLength))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode message: [%v]", err)
+	}
+
+	count, err := d.uint32()
+	if err != nil {
+		return nil, fmt.Errorf("could not decode commitment count: [%v]", err)
+	}
+	// Check the count against the remaining bytes before allocating so that
+	// a forged count can not exhaust the memory.
+	commitmentLength := signerIndexLength + 2*curve.SerializedPointLength()
+	if uint64(count)*uint64(commitmentLength) > uint64(d.remaining()) {
+		return nil, fmt.Errorf(
+			"commitment count [%d] exceeds the remaining [%d] bytes",
+			count,
+			d.remaining(),
+		)
+	}
+
+	commitments := make([]*NonceCommitment, count)
+	for i := range commitments {
+		commitment, err := d.nonceCommitment()
+		if err != nil {
+			return nil, fmt.Errorf("could not decode commitment [%d]: [%v]", i, err)
+		}
+		if i > 0 && !isSortedAfter(commitments[i-1], commitment) {
+			return nil, fmt.Errorf(
+				"commitments are not sorted in ascending order of signer "+
+					"identifiers or contain duplicates; signer [%d] follows "+
+					"signer [%d]",
+				commitment.signerIndex,
+				commitments[i-1].signerIndex,
+			)
+		}
+		commitments[i] = commitment
+	}
+
+	if err := d.finish(); err != nil {
+		return nil, fmt.Errorf("could not decode signing package: [%v]", err)
+	}
+
+	return &SigningPackage{
+		// The message must not share the memory with the encoded bytes.
+		Message:     append([]byte{}, message...),
+		Commitments: commitments,
+	}, nil
+}
+
+// isSortedAfter returns true if the commitment can follow the previous one on
+// the commitment list sorted in ascending order of signer identifiers.
+func isSortedAfter(previous, commitment *NonceCommitment) bool {
+	return previous.signerIndex < commitment.signerIndex
+}
+
+func encodeHeader(messageType byte) []byte {
+	return []byte{EncodingVersion, messageType}
+}
+
+func appendNonceCommitment(
+	curve Curve,
+	b []byte,
+	commitment *NonceCommitment,
+) ([]byte, error) {
+	if commitment == nil {
+		return nil, fmt.Errorf("nonce commitment is nil")
+	}
+	if commitment.signerIndex == 0 {
+		return nil, fmt.Errorf("signer identifier must be greater than zero")
+	}
+
+	b = binary.BigEndian.AppendUint64(b, commitment.signerIndex)
+
+	b, err := appendPoint(curve, b, commitment.hidingNonceCommitment)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hiding nonce commitment: [%v]", err)
+	}
+	b, err = appendPoint(curve, b, commitment.bindingNonceCommitment)
+	if err != nil {
+		return nil, fmt.Errorf("invalid binding nonce commitment: [%v]", err)
+	}
+
+	return b, nil
+}
+
+func appendPoint(curve Curve, b []byte, point *Point) ([]byte, error) {
+	if point == nil || point.X == nil || point.Y == nil ||
+		!curve.IsPointOnCurve(point) {
+		return nil, fmt.Errorf("not a valid non-identity point on the curve")
+	}
+	return append(b, curve.SerializePoint(point)...), nil
+}
+
+func appendScalar(curve Curve, b []byte, scalar *big.Int) ([]byte, error) {
+	if scalar == nil || scalar.Sign() < 0 || scalar.Cmp(curve.Order()) >= 0 {
+		return nil, fmt.Errorf("not a valid scalar")
+	}
+	return append(b, SerializeScalar(curve, scalar)...), nil
+}
+
+// SerializeScalar serializes the scalar as a fixed-length big-endian integer
+// of SerializedScalarLength bytes, the same way scalars are encoded in
+// [FROST] protocol messages. Zero is serialized as a non-empty byte slice.
+// The value must be a valid scalar, that is, it must be non-negative and
+// lower than the group order.
+func SerializeScalar(curve Curve, scalar *big.Int) []byte {
+	return scalar.FillBytes(make([]byte, SerializedScalarLength(curve)))
+}
+
+// DeserializeScalar deserializes the scalar serialized with SerializeScalar.
+// The function returns an error if the byte slice is not of the expected
+// length or if the value is not lower than the group order.
+func DeserializeScalar(curve Curve, b []byte) (*big.Int, error) {
+	if len(b) != SerializedScalarLength(curve) {
+		return nil, fmt.Errorf(
+			"scalar must be [%d] bytes long; has [%d]",
+			SerializedScalarLength(curve),
+			len(b),
+		)
+	}
+
+	scalar := new(big.Int).SetBytes(b)
+	if scalar.Cmp(curve.Order()) >= 0 {
+		return nil, fmt.Errorf("scalar is not lower than the group order")
+	}
+
+	return scalar, nil
+}
+
+// SerializedScalarLength returns the byte length of a serialized scalar.
+func SerializedScalarLength(curve Curve) int {
+	return (curve.Order().BitLen() + 7) / 8
+}
+
+// decoder reads the encoded message field by field, validating every field
+// read.
+type decoder struct {
+	curve Curve
+	b     []byte
+}
+
+func newDecoder(curve Curve, b []byte, messageType byte) (*decoder, error) {
+	d := &decoder{curve: curve, b: b}
+
+	header, err := d.next(2)
+	if err != nil {
+		return nil, err
+	}
+	if header[0] != EncodingVersion {
+		return nil, fmt.Errorf(
+			"unsupported encoding version [%d]; expected [%d]",
+			header[0],
+			EncodingVersion,
+		)
+	}
+	if header[1] != messageType {
+		return nil, fmt.Errorf(
+			"unexpected message type [%d]; expected [%d]",
+			header[1],
+			messageType,
+		)
+	}
+
+	return d, nil
+}
+
+func (d *decoder) next(n int) ([]byte, error) {
+	if n > len(d.b) {
+		return nil, fmt.Errorf(
+			"unexpected end of data; expected [%d] bytes, has [%d] bytes",
+			n,
+			len(d.b),
+		)
+	}
+
+	next := d.b[:n:n]
+	d.b = d.b[n:]
+	return next, nil
+}
+
+func (d *decoder) remaining() int {
+	return len(d.b)
+}
+
+func (d *decoder) finish() error {
+	if len(d.b) != 0 {
+		return fmt.Errorf("unexpected [%d] trailing bytes", len(d.b))
+	}
+	return nil
+}
+
+func (d *decoder) uint32() (uint32, error) {
+	b, err := d.next(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(b), nil
+}
+
+func (d *decoder) point() (*Point, error) {
+	b, err := d.next(d.curve.SerializedPointLength())
+	if err != nil {
+		return nil, err
+	}
+
+	point := d.curve.DeserializePoint(b)
+	if point == nil {
+		return nil, fmt.Errorf("not a valid non-identity point on the curve")
+	}
+
+	return point, nil
+}
+
+func (d *decoder) scalar() (*big.Int, error) {
+	b, err := d.next(SerializedScalarLength(d.curve))
+	if err != nil {
+		return nil, err
+	}
+
+	return DeserializeScalar(d.curve, b)
+}
+
+func (d *decoder) nonceCommitment() (*NonceCommitment, error) {
+	b, err := d.next(signerIndexLength)
+	if err != nil {
+		return nil, err
+	}
+	signerIndex := binary.BigEndian.Uint64(b)
+	if signerIndex == 0 {
+		return nil, fmt.Errorf("signer identifier must be greater than zero")
+	}
+
+	hiding, err := d.point()
+	if err != nil {
+		return nil, fmt.Errorf("invalid hiding nonce commitment: [%v]", err)
+	}
+	binding, err := d.point()
+	if err != nil {
+		return nil, fmt.Errorf("invalid binding nonce commitment: [%v]", err)
+	}
+
+	return &NonceCommitment{
+		signerIndex:            signerIndex,
+		hidingNonceCommitment:  hiding,
+		bindingNonceCommitment: binding,
+	}, nil
+}
@@ -0,0 +1,554 @@
+package frost
+
+import (
+	"math/big"
+	"testing"
+
+	"threshold.network/roast/internal/testutils"
+)
+
+func TestNonceCommitmentEncoding(t *testing.T) {
+	curve := ciphersuite.Curve()
+	commitment := newPoolCommitment(3, 5)
+
+	encoded, err := EncodeNonceCommitment(curve, commitment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertIntsEqual(
+		t,
+		"encoded length",
+		2+8+2*curve.SerializedPointLength(),
+		len(encoded),
+	)
+
+	decoded, err := DecodeNonceCommitment(curve, encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNonceCommitmentsEqual(t, commitment, decoded)
+}
+
+func TestSignatureShareEncoding(t *testing.T) {
+	curve := ciphersuite.Curve()
+	order := curve.Order()
+
+	shares := map[string]*big.Int{
+		"zero":          big.NewInt(0),
+		"small":         big.NewInt(7),
+		"order - 1":     new(big.Int).Sub(order, big.NewInt(1)),
+		"random scalar": ciphersuite.H1([]byte("share")),
+	}
+
+	for testName, share := range shares {
+		t.Run(testName, func(t *testing.T) {
+			encoded, err := EncodeSignatureShare(curve, share)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutils.AssertIntsEqual(t, "encoded length", 34, len(encoded))
+
+			decoded, err := DecodeSignatureShare(curve, encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutils.AssertBigIntsEqual(t, "signature share", share, decoded)
+		})
+	}
+}
+
+func TestScalarSerialization(t *testing.T) {
+	curve := ciphersuite.Curve()
+	order := curve.Order()
+
+	scalars := map[string]*big.Int{
+		"zero":      big.NewInt(0),
+		"small":     big.NewInt(7),
+		"order - 1": new(big.Int).Sub(order, big.NewInt(1)),
+	}
+
+	for testName, scalar := range scalars {
+		t.Run(testName, func(t *testing.T) {
+			serialized := SerializeScalar(curve, scalar)
+			testutils.AssertIntsEqual(
+				t,
+				"serialized length",
+				SerializedScalarLength(curve),
+				len(serialized),
+			)
+
+			deserialized, err := DeserializeScalar(curve, serialized)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutils.AssertBigIntsEqual(t, "scalar", scalar, deserialized)
+		})
+	}
+}
+
+func TestDeserializeScalar_Failures(t *testing.T) {
+	curve := ciphersuite.Curve()
+
+	tests := map[string]struct {
+		serialized  []byte
+		expectedErr string
+	}{
+		"empty": {
+			serialized:  nil,
+			expectedErr: "scalar must be [32] bytes long; has [0]",
+		},
+		"too long": {
+			serialized:  make([]byte, 33),
+			expectedErr: "scalar must be [32] bytes long; has [33]",
+		},
+		"group order": {
+			serialized:  curve.Order().FillBytes(make([]byte, 32)),
+			expectedErr: "scalar is not lower than the group order",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, err := DeserializeScalar(curve, test.serialized)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			testutils.AssertStringsEqual(
+				t,
+				"deserialization error",
+				test.expectedErr,
+				err.Error(),
+			)
+		})
+	}
+}
+
+func TestSignatureEncoding(t *testing.T) {
+	curve := ciphersuite.Curve()
+	signature := &Signature{
+		R: curve.EcBaseMul(big.NewInt(11)),
+		Z: ciphersuite.H1([]byte("z")),
+	}
+
+	encoded, err := EncodeSignature(curve, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := DecodeSignature(curve, encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBytesEqual(
+		t,
+		curve.SerializePoint(signature.R),
+		curve.SerializePoint(decoded.R),
+	)
+	testutils.AssertBigIntsEqual(t, "z", signature.Z, decoded.Z)
+}
+
+func TestSigningPackageEncoding(t *testing.T) {
+	curve := ciphersuite.Curve()
+
+	tests := map[string]*SigningPackage{
+		"message and commitments": {
+			Message: []byte("For even the very wise cannot see all ends"),
+			Commitments: []*NonceCommitment{
+				newPoolCommitment(1, 1),
+				newPoolCommitment(4, 2),
+				newPoolCommitment(9, 3),
+			},
+		},
+		"empty message": {
+			Message:     []byte{},
+			Commitments: []*NonceCommitment{newPoolCommitment(2, 1)},
+		},
+	}
+
+	for testName, signingPackage := range tests {
+		t.Run(testName, func(t *testing.T) {
+			encoded, err := EncodeSigningPackage(curve, signingPackage)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			decoded, err := DecodeSigningPackage(curve, encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBytesEqual(t, signingPackage.Message, decoded.Message)
+			testutils.AssertIntsEqual(
+				t,
+				"number of commitments",
+				len(signingPackage.Commitments),
+				len(decoded.Commitments),
+			)
+			for i, commitment := range signingPackage.Commitments {
+				assertNonceCommitmentsEqual(t, commitment, decoded.Commitments[i])
+			}
+		})
+	}
+}
+
+func TestSigningPackageEncoding_Signing(t *testing.T) {
+	curve := ciphersuite.Curve()
+	message := []byte("For even the very wise cannot see all ends")
+
+	signers := createSigners(t)[:threshold]
+	commitments := executeRound1(t, signers)
+
+	encoded, err := EncodeSigningPackage(curve, &SigningPackage{
+		Message:     message,
+		Commitments: commitments,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingPackage, err := DecodeSigningPackage(curve, encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signatureShares := make([]*big.Int, len(signers))
+	for i, signer := range signers {
+		share, err := signer.Round2(
+			signingPackage.Message,
+			signingPackage.Commitments[i],
+			signingPackage.Commitments,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encodedShare, err := EncodeSignatureShare(curve, share)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signatureShares[i], err = DecodeSignatureShare(curve, encodedShare)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	coordinator := NewCoordinator(
+		ciphersuite,
+		signers[0].publicKey,
+		threshold,
+		groupSize,
+		verificationShares(signers),
+	)
+	signature, err := coordinator.Aggregate(message, commitments, signatureShares)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encodedSignature, err := EncodeSignature(curve, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decodedSignature, err := DecodeSignature(curve, encodedSignature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid, err := ciphersuite.VerifySignature(
+		decodedSignature,
+		signers[0].publicKey,
+		message,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "signature validity", true, valid)
+}
+
+func TestDecoding_Errors(t *testing.T) {
+	curve := ciphersuite.Curve()
+	order := curve.Order()
+
+	commitment, err := EncodeNonceCommitment(curve, newPoolCommitment(3, 5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	share, err := EncodeSignatureShare(curve, big.NewInt(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := EncodeSignature(curve, &Signature{
+		R: curve.EcBaseMul(big.NewInt(11)),
+		Z: big.NewInt(13),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingPackage, err := EncodeSigningPackage(curve, &SigningPackage{
+		Message:     []byte("message"),
+		Commitments: []*NonceCommitment{newPoolCommitment(1, 1)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The hiding nonce commitment starts right after the header and the
+	// signer identifier, the binding one right after the hiding one.
+	hidingOffset := 2 + 8
+	bindingOffset := hidingOffset + curve.SerializedPointLength()
+	commitmentOffset := 2 + 4 + len("message") + 4
+
+	decodeCommitment := func(b []byte) error {
+		_, err := DecodeNonceCommitment(curve, b)
+		return err
+	}
+	decodeShare := func(b []byte) error {
+		_, err := DecodeSignatureShare(curve, b)
+		return err
+	}
+	decodeSignature := func(b []byte) error {
+		_, err := DecodeSignature(curve, b)
+		return err
+	}
+	decodeSigningPackage := func(b []byte) error {
+		_, err := DecodeSigningPackage(curve, b)
+		return err
+	}
+
+	tests := map[string]struct {
+		decode      func([]byte) error
+		encoded     []byte
+		expectedErr string
+	}{
+		"empty data": {
+			decode:  decodeCommitment,
+			encoded: []byte{},
+			expectedErr: "could not decode nonce commitment: [unexpected end " +
+				"of data; expected [2] bytes, has [0] bytes]",
+		},
+		"unsupported version": {
+			decode:  decodeCommitment,
+			encoded: modified(commitment, 0, 0x02),
+			expectedErr: "could not decode nonce commitment: [unsupported " +
+				"encoding version [2]; expected [1]]",
+		},
+		"unexpected message type": {
+			decode:  decodeCommitment,
+			encoded: share,
+			expectedErr: "could not decode nonce commitment: [unexpected " +
+				"message type [2]; expected [1]]",
+		},
+		"zero signer identifier": {
+			decode:  decodeCommitment,
+			encoded: modified(commitment, 9, 0x00),
+			expectedErr: "could not decode nonce commitment: [signer " +
+				"identifier must be greater than zero]",
+		},
+		"hiding nonce commitment not on the curve": {
+			decode:  decodeCommitment,
+			encoded: modified(commitment, hidingOffset+10, 0xff),
+			expectedErr: "could not decode nonce commitment: [invalid hiding " +
+				"nonce commitment: [not a valid non-identity point on the curve]]",
+		},
+		"binding nonce commitment not on the curve": {
+			decode:  decodeCommitment,
+			encoded: modified(commitment, bindingOffset+10, 0xff),
+			expectedErr: "could not decode nonce commitment: [invalid binding " +
+				"nonce commitment: [not a valid non-identity point on the curve]]",
+		},
+		"truncated commitment": {
+			decode:  decodeCommitment,
+			encoded: commitment[:len(commitment)-1],
+			expectedErr: "could not decode nonce commitment: [invalid binding " +
+				"nonce commitment: [unexpected end of data; expected [65] " +
+				"bytes, has [64] bytes]]",
+		},
+		"commitment with trailing bytes": {
+			decode:      decodeCommitment,
+			encoded:     append(clone(commitment), 0x00),
+			expectedErr: "could not decode nonce commitment: [unexpected [1] trailing bytes]",
+		},
+		"signature share equal to the group order": {
+			decode:  decodeShare,
+			encoded: append([]byte{0x01, 0x02}, order.Bytes()...),
+			expectedErr: "could not decode signature share: [scalar is not " +
+				"lower than the group order]",
+		},
+		"signature share with trailing bytes": {
+			decode:      decodeShare,
+			encoded:     append(clone(share), 0x00, 0x00),
+			expectedErr: "could not decode signature share: [unexpected [2] trailing bytes]",
+		},
+		"signature R not on the curve": {
+			decode:  decodeSignature,
+			encoded: modified(signature, 12, 0xff),
+			expectedErr: "could not decode signature R: [not a valid " +
+				"non-identity point on the curve]",
+		},
+		"signature z equal to the group order": {
+			decode: decodeSignature,
+			encoded: append(
+				clone(signature[:2+curve.SerializedPointLength()]),
+				order.Bytes()...,
+			),
+			expectedErr: "could not decode signature z: [scalar is not lower " +
+				"than the group order]",
+		},
+		"signature with trailing bytes": {
+			decode:      decodeSignature,
+			encoded:     append(clone(signature), 0x00),
+			expectedErr: "could not decode signature: [unexpected [1] trailing bytes]",
+		},
+		"message longer than the data": {
+			decode:  decodeSigningPackage,
+			encoded: modified(signingPackage, 4, 0xff),
+			expectedErr: "could not decode message: [unexpected end of data; " +
+				"expected [65287] bytes, has [149] bytes]",
+		},
+		"commitment count exceeding the data": {
+			decode:  decodeSigningPackage,
+			encoded: modified(signingPackage, commitmentOffset-1, 0x02),
+			expectedErr: "commitment count [2] exceeds the remaining [138] " +
+				"bytes",
+		},
+		"invalid commitment in the signing package": {
+			decode:  decodeSigningPackage,
+			encoded: modified(signingPackage, commitmentOffset+7, 0x00),
+			expectedErr: "could not decode commitment [0]: [signer identifier " +
+				"must be greater than zero]",
+		},
+		"signing package with trailing bytes": {
+			decode:  decodeSigningPackage,
+			encoded: append(clone(signingPackage), 0x00),
+			expectedErr: "could not decode signing package: [unexpected [1] " +
+				"trailing bytes]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := test.decode(test.encoded)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			testutils.AssertStringsEqual(t, "decoding error", test.expectedErr, err.Error())
+		})
+	}
+}
+
+func TestDecodeSigningPackage_Unsorted(t *testing.T) {
+	curve := ciphersuite.Curve()
+
+	encoded, err := EncodeSigningPackage(curve, &SigningPackage{
+		Message: []byte("message"),
+		Commitments: []*NonceCommitment{
+			newPoolCommitment(1, 1),
+			newPoolCommitment(2, 2),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Change the identifier of the second signer to the identifier of the
+	// first one.
+	second := 2 + 4 + len("message") + 4 + 8 + 2*curve.SerializedPointLength()
+	encoded[second+7] = 0x01
+
+	_, err = DecodeSigningPackage(curve, encoded)
+	testutils.AssertStringsEqual(
+		t,
+		"decoding error",
+		"commitments are not sorted in ascending order of signer identifiers "+
+			"or contain duplicates; signer [1] follows signer [1]",
+		err.Error(),
+	)
+}
+
+func TestEncoding_Errors(t *testing.T) {
+	curve := ciphersuite.Curve()
+
+	tests := map[string]struct {
+		encode      func() error
+		expectedErr string
+	}{
+		"nil nonce commitment": {
+			encode: func() error {
+				_, err := EncodeNonceCommitment(curve, nil)
+				return err
+			},
+			expectedErr: "nonce commitment is nil",
+		},
+		"nonce commitment with the identity element": {
+			encode: func() error {
+				commitment := newPoolCommitment(1, 1)
+				commitment.hidingNonceCommitment = curve.Identity()
+				_, err := EncodeNonceCommitment(curve, commitment)
+				return err
+			},
+			expectedErr: "invalid hiding nonce commitment: [not a valid " +
+				"non-identity point on the curve]",
+		},
+		"signature share out of range": {
+			encode: func() error {
+				_, err := EncodeSignatureShare(curve, curve.Order())
+				return err
+			},
+			expectedErr: "not a valid scalar",
+		},
+		"signature with nil R": {
+			encode: func() error {
+				_, err := EncodeSignature(curve, &Signature{Z: big.NewInt(1)})
+				return err
+			},
+			expectedErr: "invalid R: [not a valid non-identity point on the curve]",
+		},
+		"unsorted signing package": {
+			encode: func() error {
+				_, err := EncodeSigningPackage(curve, &SigningPackage{
+					Commitments: []*NonceCommitment{
+						newPoolCommitment(2, 1),
+						newPoolCommitment(1, 2),
+					},
+				})
+				return err
+			},
+			expectedErr: "commitment [1] is not sorted in ascending order of " +
+				"signer identifiers or is duplicated",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := test.encode()
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			testutils.AssertStringsEqual(t, "encoding error", test.expectedErr, err.Error())
+		})
+	}
+}
+
+func assertNonceCommitmentsEqual(t *testing.T, expected, actual *NonceCommitment) {
+	curve := ciphersuite.Curve()
+
+	testutils.AssertUintsEqual(t, "signer index", expected.signerIndex, actual.signerIndex)
+	testutils.AssertBytesEqual(
+		t,
+		curve.SerializePoint(expected.hidingNonceCommitment),
+		curve.SerializePoint(actual.hidingNonceCommitment),
+	)
+	testutils.AssertBytesEqual(
+		t,
+		curve.SerializePoint(expected.bindingNonceCommitment),
+		curve.SerializePoint(actual.bindingNonceCommitment),
+	)
+}
+
+func modified(b []byte, index int, value byte) []byte {
+	c := clone(b)
+	c[index] = value
+	return c
+}
+
+func clone(b []byte) []byte {
+	return append([]byte{}, b...)
+}
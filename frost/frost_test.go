@@ -2,7 +2,6 @@ package frost
 
 import (
 	"crypto/rand"
-	"fmt"
 	"math/big"
 	"testing"
 
@@ -13,76 +12,111 @@ var ciphersuite = NewBip340Ciphersuite()
 var threshold = 51
 var groupSize = 100
 
+// Tests signing many times use a small group to keep them fast.
+var smallThreshold = 3
+var smallGroupSize = 5
+
 func TestFrostRoundtrip(t *testing.T) {
 	message := []byte("For even the very wise cannot see all ends")
 
+	// The group commitment has an odd Y coordinate in about half of the
+	// signings. Signing a few times covers both cases and every signature
+	// must be valid. Signing is repeated only in the small group; the large
+	// group signs once.
 	tests := map[string]struct {
+		threshold       int
+		groupSize       int
 		numberOfSigners int
+		oddY            bool
+		signings        int
 	}{
-		"the entire group": {
-			numberOfSigners: groupSize,
+		"the entire group, even Y of the public key": {
+			threshold:       smallThreshold,
+			groupSize:       smallGroupSize,
+			numberOfSigners: smallGroupSize,
+			oddY:            false,
+			signings:        4,
+		},
+		"the entire group, odd Y of the public key": {
+			threshold:       smallThreshold,
+			groupSize:       smallGroupSize,
+			numberOfSigners: smallGroupSize,
+			oddY:            true,
+			signings:        4,
+		},
+		"threshold of the group, even Y of the public key": {
+			threshold:       smallThreshold,
+			groupSize:       smallGroupSize,
+			numberOfSigners: smallThreshold,
+			oddY:            false,
+			signings:        4,
 		},
-		"threshold of the group": {
+		"threshold of the group, odd Y of the public key": {
+			threshold:       smallThreshold,
+			groupSize:       smallGroupSize,
+			numberOfSigners: smallThreshold,
+			oddY:            true,
+			signings:        4,
+		},
+		"threshold of a large group": {
+			threshold:       threshold,
+			groupSize:       groupSize,
 			numberOfSigners: threshold,
+			oddY:            true,
+			signings:        1,
 		},
 	}
 
 	for testName, test := range tests {
 		t.Run(testName, func(t *testing.T) {
-			signers := createSigners(t)[:test.numberOfSigners]
+			secretKey := generateSecretKey(t, test.oddY)
+			signers := createGroupSigners(
+				t,
+				secretKey,
+				test.threshold,
+				test.groupSize,
+			)[:test.numberOfSigners]
 			publicKey := signers[0].publicKey
 
-			isSignatureValid := false
-			maxAttempts := 20
-
-			// From [BIP-340]:
-			// Let k' = int(rand) mod n[13].
-			// Fail if k' = 0.
-			// Let R = k'⋅G.
-			// Let k = k' if has_even_y(R), otherwise let k = n - k' .
-			//
-			// Although it is easy to address similar requirement for `d` by
-			// inverting the secret key earlier in the test, for `k` the
-			// situation is more complicated because it is generated by [FROST].
-			// For now, we just retry.
-			//
-			// See https://github.com/threshold-network/roast-go/issues/12
-			for i := 0; !isSignatureValid && i < maxAttempts; i++ {
-				nonces, commitments := executeRound1(t, signers)
-				signatureShares := executeRound2(t, signers, message, nonces, commitments)
-
-				coordinator := NewCoordinator(ciphersuite, publicKey, threshold, groupSize)
+			for i := 0; i < test.signings; i++ {
+				commitments := executeRound1(t, signers)
+				signatureShares := executeRound2(t, signers, message, commitments)
+
+				coordinator := NewCoordinator(
+					ciphersuite,
+					publicKey,
+					test.threshold,
+					test.groupSize,
+					verificationShares(signers),
+				)
 				signature, err := coordinator.Aggregate(message, commitments, signatureShares)
 				if err != nil {
 					t.Fatal(err)
 				}
 
-				isSignatureValid, err = ciphersuite.VerifySignature(
+				isSignatureValid, err := ciphersuite.VerifySignature(
 					signature,
 					publicKey,
 					message,
 				)
 				if err != nil {
-					fmt.Printf(
-						"[%v] signature verification error on attempt [%v]: [%v]\n",
-						testName,
-						i,
-						err,
-					)
+					t.Fatalf("signing [%d]: [%v]", i, err)
 				}
-			}
 
-			testutils.AssertBoolsEqual(
-				t,
-				"signature verification result",
-				true,
-				isSignatureValid,
-			)
+				testutils.AssertBoolsEqual(
+					t,
+					"signature verification result",
+					true,
+					isSignatureValid,
+				)
+			}
 		})
 	}
 }
 
-func createSigners(t *testing.T) []*Signer {
+// generateSecretKey generates a random group secret key such that the Y
+// coordinate of the group public key is odd or even, as requested.
+func generateSecretKey(t *testing.T, oddY bool) *big.Int {
 	curve := ciphersuite.Curve()
 	order := curve.Order()
 
@@ -92,67 +126,102 @@ func createSigners(t *testing.T) []*Signer {
 	}
 
 	publicKey := curve.EcBaseMul(secretKey)
-
-	// From [BIP-340]:
-	// Let d' = int(sk)
-	// Fail if d' = 0 or d' ≥ n
-	// Let P = d'⋅G
-	// Let d = d' if has_even_y(P), otherwise let d = n - d' .
-	if publicKey.Y.Bit(0) != 0 { // is Y even?
+	if (publicKey.Y.Bit(0) != 0) != oddY {
 		secretKey.Sub(order, secretKey)
-		publicKey = curve.EcBaseMul(secretKey)
 	}
 
+	return secretKey
+}
+
+func createSigners(t *testing.T) []*Signer {
+	curve := ciphersuite.Curve()
+
+	secretKey, err := rand.Int(rand.Reader, curve.Order())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return createSignersForKey(t, secretKey)
+}
+
+func createSignersForKey(t *testing.T, secretKey *big.Int) []*Signer {
+	return createGroupSigners(t, secretKey, threshold, groupSize)
+}
+
+func createGroupSigners(
+	t *testing.T,
+	secretKey *big.Int,
+	threshold int,
+	groupSize int,
+) []*Signer {
+	curve := ciphersuite.Curve()
+	publicKey := curve.EcBaseMul(secretKey)
+
 	keyShares := testutils.GenerateKeyShares(
 		secretKey,
 		groupSize,
 		threshold,
-		order,
+		curve.Order(),
 	)
 
 	signers := make([]*Signer, groupSize)
 
 	for i := 0; i < groupSize; i++ {
 		j := i + 1
-		signers[i] = NewSigner(ciphersuite, uint64(j), publicKey, keyShares[i])
+		signers[i] = NewSigner(
+			ciphersuite,
+			uint64(j),
+			publicKey,
+			threshold,
+			groupSize,
+			keyShares[i],
+		)
 	}
 
 	return signers
 }
 
+// verificationShares returns the verification shares PK_i of the signers,
+// indexed by the signer identifier.
+func verificationShares(signers []*Signer) map[uint64]*Point {
+	curve := ciphersuite.Curve()
+	shares := make(map[uint64]*Point, len(signers))
+	for _, signer := range signers {
+		shares[signer.signerIndex] = curve.EcBaseMul(signer.secretKeyShare)
+	}
+	return shares
+}
+
 func executeRound1(
 	t *testing.T,
 	signers []*Signer,
-) ([]*Nonce, []*NonceCommitment) {
-	nonces := make([]*Nonce, len(signers))
+) []*NonceCommitment {
 	commitments := make([]*NonceCommitment, len(signers))
 
 	for i, signer := range signers {
-		n, c, err := signer.Round1()
+		c, err := signer.Round1()
 		if err != nil {
-			t.Fatal(t)
+			t.Fatal(err)
 		}
 
-		nonces[i] = n
 		commitments[i] = c
 	}
 
-	return nonces, commitments
+	return commitments
 }
 
 func executeRound2(
 	t *testing.T,
 	signers []*Signer,
 	message []byte,
-	nonces []*Nonce,
 	nonceCommitments []*NonceCommitment,
 ) []*big.Int {
 	signatureShares := make([]*big.Int, len(signers))
 
 	for i, signer := range signers {
-		signatureShare, err := signer.Round2(message, nonces[i], nonceCommitments)
+		signatureShare, err := signer.Round2(message, nonceCommitments[i], nonceCommitments)
 		if err != nil {
-			t.Fatal(t)
+			t.Fatal(err)
 		}
 
 		signatureShares[i] = signatureShare
@@ -0,0 +1,258 @@
+package frost
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// NonceStore keeps nonces generated by the Signer in Round One until they are
+// used in Round Two. Nonces are stored under their commitments and every
+// nonce can be taken from the store only once.
+//
+// From [FROST], section 5.2. Round Two - Signature Share Generation:
+//
+//	(...) each participant MUST delete the nonce and corresponding
+//	commitment after completing sign, and MUST NOT use the nonce as input
+//	more than once to sign.
+//
+// Signing twice with the same nonce leaks the secret key share so the store
+// implementation must guarantee the nonce is never returned twice, even to
+// concurrent callers.
+type NonceStore interface {
+	// Put stores the nonce under the commitment. The function returns an
+	// error if a nonce for the commitment was already stored.
+	Put(commitment *NonceCommitment, nonce *Nonce) error
+
+	// Take atomically removes the nonce stored for the commitment and
+	// returns it. The function returns an error if there is no nonce for the
+	// commitment, either because it was never stored or because it was
+	// already taken.
+	Take(commitment *NonceCommitment) (*Nonce, error)
+}
+
+// ErrNonceNotFound is returned from NonceStore.Take if there is no nonce for
+// the commitment.
+var ErrNonceNotFound = errors.New("nonce not found")
+
+// nonceKey returns a unique key of the nonce commitment. The key does not
+// depend on the ciphersuite and can be used as a file name.
+func nonceKey(commitment *NonceCommitment) (string, error) {
+	hiding := commitment.hidingNonceCommitment
+	binding := commitment.bindingNonceCommitment
+	if hiding == nil || hiding.X == nil || hiding.Y == nil ||
+		binding == nil || binding.X == nil || binding.Y == nil {
+		return "", fmt.Errorf("nonce commitment is incomplete")
+	}
+
+	hash := sha256.Sum256([]byte(fmt.Sprintf(
+		"%d|%x|%x|%x|%x",
+		commitment.signerIndex,
+		hiding.X,
+		hiding.Y,
+		binding.X,
+		binding.Y,
+	)))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// MemoryNonceStore is an in-memory NonceStore implementation. Nonces do not
+// survive a restart of the process.
+type MemoryNonceStore struct {
+	mutex  sync.Mutex
+	nonces map[string]*Nonce
+}
+
+// NewMemoryNonceStore creates a new, empty MemoryNonceStore.
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{
+		nonces: make(map[string]*Nonce),
+	}
+}
+
+// Put stores the nonce under the commitment.
+func (mns *MemoryNonceStore) Put(commitment *NonceCommitment, nonce *Nonce) error {
+	key, err := nonceKey(commitment)
+	if err != nil {
+		return err
+	}
+
+	mns.mutex.Lock()
+	defer mns.mutex.Unlock()
+
+	if _, ok := mns.nonces[key]; ok {
+		return fmt.Errorf("nonce for the commitment is already stored")
+	}
+	mns.nonces[key] = nonce
+
+	return nil
+}
+
+// Take removes the nonce stored for the commitment and returns it.
+func (mns *MemoryNonceStore) Take(commitment *NonceCommitment) (*Nonce, error) {
+	key, err := nonceKey(commitment)
+	if err != nil {
+		return nil, err
+	}
+
+	mns.mutex.Lock()
+	defer mns.mutex.Unlock()
+
+	nonce, ok := mns.nonces[key]
+	if !ok {
+		return nil, ErrNonceNotFound
+	}
+	delete(mns.nonces, key)
+
+	return nonce, nil
+}
+
+// FileNonceStore is a file-backed NonceStore implementation. Every nonce is
+// stored in a separate file in the store directory, named after the nonce
+// commitment. The file and the store directory are synced before Put
+// returns.
+//
+// Take renames the nonce file before reading it. The rename is atomic so
+// only one caller can take the nonce, even if several processes share the
+// directory. The store directory is synced after the rename, before the nonce
+// is returned, so that the rename survives a crash. The renamed file is
+// removed once the nonce is read. If the process crashes in between, the
+// renamed file is never read again so the nonce is lost but never used
+// twice.
+//
+// Note that the store directory holds secret nonces and must be protected the
+// same way as the secret key share.
+type FileNonceStore struct {
+	dir string
+}
+
+// fileNonce is the JSON representation of the nonce stored in the file.
+// Scalars are encoded as big-endian byte slices.
+type fileNonce struct {
+	HidingNonce  []byte `json:"hidingNonce"`
+	BindingNonce []byte `json:"bindingNonce"`
+}
+
+// NewFileNonceStore creates a new FileNonceStore in the given directory,
+// creating the directory if it does not exist. Nonces already stored in the
+// directory are available for Take.
+func NewFileNonceStore(dir string) (*FileNonceStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("could not create nonce store directory: [%v]", err)
+	}
+
+	return &FileNonceStore{dir: dir}, nil
+}
+
+// Put writes the nonce to a new file in the store directory and syncs the
+// file and the directory.
+func (fns *FileNonceStore) Put(commitment *NonceCommitment, nonce *Nonce) error {
+	key, err := nonceKey(commitment)
+	if err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(&fileNonce{
+		HidingNonce:  nonce.hidingNonce.Bytes(),
+		BindingNonce: nonce.bindingNonce.Bytes(),
+	})
+	if err != nil {
+		return fmt.Errorf("could not encode nonce: [%v]", err)
+	}
+
+	// The nonce is written to a temporary file first so that a partially
+	// written nonce is never taken.
+	temp, err := os.CreateTemp(fns.dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("could not create nonce file: [%v]", err)
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.Write(content); err != nil {
+		temp.Close()
+		return fmt.Errorf("could not write nonce file: [%v]", err)
+	}
+	if err := temp.Sync(); err != nil {
+		temp.Close()
+		return fmt.Errorf("could not sync nonce file: [%v]", err)
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("could not close nonce file: [%v]", err)
+	}
+
+	// Link fails if the nonce file already exists, so a nonce once stored is
+	// never overwritten.
+	if err := os.Link(temp.Name(), fns.path(key)); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("nonce for the commitment is already stored")
+		}
+		return fmt.Errorf("could not store nonce file: [%v]", err)
+	}
+
+	if err := syncDir(fns.dir); err != nil {
+		return fmt.Errorf("could not sync nonce store directory: [%v]", err)
+	}
+
+	return nil
+}
+
+// Take renames the nonce file, reads the nonce, and removes the file.
+func (fns *FileNonceStore) Take(commitment *NonceCommitment) (*Nonce, error) {
+	key, err := nonceKey(commitment)
+	if err != nil {
+		return nil, err
+	}
+
+	taken := fns.path(key) + ".taken"
+	if err := os.Rename(fns.path(key), taken); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNonceNotFound
+		}
+		return nil, fmt.Errorf("could not take nonce file: [%v]", err)
+	}
+	defer os.Remove(taken)
+
+	// The nonce must not be returned before the rename is durable.
+	// Otherwise, the nonce file could reappear after a crash and the nonce
+	// could be taken again.
+	if err := syncDir(fns.dir); err != nil {
+		return nil, fmt.Errorf("could not sync nonce store directory: [%v]", err)
+	}
+
+	content, err := os.ReadFile(taken)
+	if err != nil {
+		return nil, fmt.Errorf("could not read nonce file: [%v]", err)
+	}
+
+	fn := &fileNonce{}
+	if err := json.Unmarshal(content, fn); err != nil {
+		return nil, fmt.Errorf("could not decode nonce file: [%v]", err)
+	}
+
+	return NewNonce(
+		new(big.Int).SetBytes(fn.HidingNonce),
+		new(big.Int).SetBytes(fn.BindingNonce),
+	), nil
+}
+
+func (fns *FileNonceStore) path(key string) string {
+	return filepath.Join(fns.dir, key+".nonce")
+}
+
+// syncDir syncs the directory so that files created, linked, or renamed in
+// it survive a crash.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
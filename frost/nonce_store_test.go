@@ -0,0 +1,238 @@
+package frost
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"sync"
+	"testing"
+
+	"threshold.network/roast/internal/testutils"
+)
+
+var nonceStores = map[string]func(t *testing.T) NonceStore{
+	"memory": func(t *testing.T) NonceStore {
+		return NewMemoryNonceStore()
+	},
+	"file": func(t *testing.T) NonceStore {
+		store, err := NewFileNonceStore(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return store
+	},
+}
+
+func TestNonceStore(t *testing.T) {
+	for storeName, newStore := range nonceStores {
+		t.Run(storeName, func(t *testing.T) {
+			store := newStore(t)
+			commitment, nonce := newTestNonce(1)
+
+			if err := store.Put(commitment, nonce); err != nil {
+				t.Fatal(err)
+			}
+
+			err := store.Put(commitment, nonce)
+			testutils.AssertStringsEqual(
+				t,
+				"second put error",
+				"nonce for the commitment is already stored",
+				err.Error(),
+			)
+
+			taken, err := store.Take(commitment)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutils.AssertBigIntsEqual(t, "hiding nonce", nonce.hidingNonce, taken.hidingNonce)
+			testutils.AssertBigIntsEqual(t, "binding nonce", nonce.bindingNonce, taken.bindingNonce)
+
+			_, err = store.Take(commitment)
+			if !errors.Is(err, ErrNonceNotFound) {
+				t.Fatalf("unexpected second take error: [%v]", err)
+			}
+		})
+	}
+}
+
+func TestNonceStore_ConcurrentTake(t *testing.T) {
+	for storeName, newStore := range nonceStores {
+		t.Run(storeName, func(t *testing.T) {
+			store := newStore(t)
+			commitment, nonce := newTestNonce(1)
+
+			if err := store.Put(commitment, nonce); err != nil {
+				t.Fatal(err)
+			}
+
+			var mutex sync.Mutex
+			taken := 0
+
+			var wg sync.WaitGroup
+			for i := 0; i < 16; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if _, err := store.Take(commitment); err == nil {
+						mutex.Lock()
+						taken++
+						mutex.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+
+			testutils.AssertIntsEqual(t, "number of successful takes", 1, taken)
+		})
+	}
+}
+
+func TestFileNonceStore_Restart(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := NewFileNonceStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitment1, nonce1 := newTestNonce(1)
+	commitment2, nonce2 := newTestNonce(2)
+	if err := store.Put(commitment1, nonce1); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(commitment2, nonce2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Take(commitment1); err != nil {
+		t.Fatal(err)
+	}
+
+	restarted, err := NewFileNonceStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := restarted.Take(commitment1); !errors.Is(err, ErrNonceNotFound) {
+		t.Fatalf("unexpected error for the taken nonce: [%v]", err)
+	}
+
+	taken, err := restarted.Take(commitment2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBigIntsEqual(t, "hiding nonce", nonce2.hidingNonce, taken.hidingNonce)
+	testutils.AssertBigIntsEqual(t, "binding nonce", nonce2.bindingNonce, taken.bindingNonce)
+}
+
+func TestFileNonceStore_RestartAfterTake(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := NewFileNonceStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitment, nonce := newTestNonce(1)
+	if err := store.Put(commitment, nonce); err != nil {
+		t.Fatal(err)
+	}
+
+	// The process crashed after Take renamed the nonce file but before the
+	// file was read and removed.
+	key, err := nonceKey(commitment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	taken := store.path(key) + ".taken"
+	if err := os.Rename(store.path(key), taken); err != nil {
+		t.Fatal(err)
+	}
+
+	restarted, err := NewFileNonceStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := restarted.Take(commitment); !errors.Is(err, ErrNonceNotFound) {
+		t.Fatalf("unexpected error for the taken nonce: [%v]", err)
+	}
+	if _, err := os.Stat(taken); err != nil {
+		t.Errorf("expected the taken nonce file to be left unread: [%v]", err)
+	}
+}
+
+func TestRound2_FileNonceStore(t *testing.T) {
+	message := []byte("For even the very wise cannot see all ends")
+
+	signers := createSigners(t)[:threshold]
+
+	dir := t.TempDir()
+	store, err := NewFileNonceStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer := signers[0]
+	signers[0] = NewSignerWithNonceStore(
+		ciphersuite,
+		signer.signerIndex,
+		signer.publicKey,
+		threshold,
+		groupSize,
+		signer.secretKeyShare,
+		store,
+	)
+
+	commitments := executeRound1(t, signers)
+
+	// The signer restarted between the rounds.
+	restarted, err := NewFileNonceStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signers[0] = NewSignerWithNonceStore(
+		ciphersuite,
+		signer.signerIndex,
+		signer.publicKey,
+		threshold,
+		groupSize,
+		signer.secretKeyShare,
+		restarted,
+	)
+
+	signatureShares := executeRound2(t, signers, message, commitments)
+
+	coordinator := NewCoordinator(
+		ciphersuite,
+		signer.publicKey,
+		threshold,
+		groupSize,
+		verificationShares(signers),
+	)
+	ok, err := coordinator.VerifySignatureShare(
+		signer.signerIndex,
+		signatureShares[0],
+		commitments,
+		message,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "signature share validity", true, ok)
+}
+
+func newTestNonce(seed int64) (*NonceCommitment, *Nonce) {
+	curve := ciphersuite.Curve()
+
+	hiding := big.NewInt(seed * 2)
+	binding := big.NewInt(seed*2 + 1)
+
+	commitment := &NonceCommitment{
+		signerIndex:            uint64(seed),
+		hidingNonceCommitment:  curve.EcBaseMul(hiding),
+		bindingNonceCommitment: curve.EcBaseMul(binding),
+	}
+
+	return commitment, NewNonce(hiding, binding)
+}
@@ -0,0 +1,73 @@
+package frost
+
+import "time"
+
+// EventKind is the kind of the event reported to the Observer.
+type EventKind string
+
+const (
+	// EventCommitmentGenerated is reported by the Signer once it generated
+	// nonces and their commitments in Round One.
+	EventCommitmentGenerated EventKind = "commitment-generated"
+
+	// EventShareGenerated is reported by the Signer once it generated the
+	// signature share in Round Two.
+	EventShareGenerated EventKind = "share-generated"
+
+	// EventShareReceived is reported by the Coordinator once the signature
+	// share of the signer was verified successfully.
+	EventShareReceived EventKind = "share-received"
+
+	// EventShareRejected is reported by the Coordinator once the signature
+	// share of the signer failed the verification. The reason of the
+	// rejection is set on the event.
+	EventShareRejected EventKind = "share-rejected"
+
+	// EventSignatureProduced is reported by the Coordinator once the
+	// signature shares were aggregated into the signature and the signature
+	// passed the verification.
+	EventSignatureProduced EventKind = "signature-produced"
+)
+
+// Event is a single event reported to the Observer. Depending on the kind,
+// only some of the fields are set.
+type Event struct {
+	Kind EventKind
+	// Time is the time the event was reported. The field is always set by
+	// the protocol reporting the event.
+	Time time.Time
+
+	// SignerIndex is the identifier of the signer the event relates to.
+	SignerIndex uint64
+	// SessionID is the identifier of the signing session the event relates
+	// to. The field is set only by protocols running many [FROST] signing
+	// sessions, like [ROAST], and is zero otherwise.
+	SessionID uint64
+	// Signers are identifiers of all signers taking part in the session.
+	Signers []uint64
+	// Reason explains why the share or the signer was rejected.
+	Reason error
+}
+
+// Observer is notified about key events of the signing protocol. Observer
+// implementations must be safe for concurrent use and should return quickly
+// as they are called synchronously by the protocol.
+type Observer interface {
+	Observe(event *Event)
+}
+
+// SetObserver sets the observer notified about the events of the
+// participant. Passing nil removes the observer.
+func (p *Participant) SetObserver(observer Observer) {
+	p.observer = observer
+}
+
+// notify sets the event time to the current time and reports the event to
+// the observer, if set.
+func (p *Participant) notify(event *Event) {
+	if p.observer == nil {
+		return
+	}
+	event.Time = time.Now()
+	p.observer.Observe(event)
+}
@@ -0,0 +1,132 @@
+package frost
+
+import (
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+
+	"threshold.network/roast/internal/testutils"
+)
+
+type recordingObserver struct {
+	mutex  sync.Mutex
+	events []*Event
+}
+
+func (ro *recordingObserver) Observe(event *Event) {
+	ro.mutex.Lock()
+	defer ro.mutex.Unlock()
+	ro.events = append(ro.events, event)
+}
+
+func (ro *recordingObserver) count(kind EventKind) int {
+	ro.mutex.Lock()
+	defer ro.mutex.Unlock()
+
+	count := 0
+	for _, event := range ro.events {
+		if event.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+func TestObserver(t *testing.T) {
+	message := []byte("For even the very wise cannot see all ends")
+
+	observer := &recordingObserver{}
+
+	signers := createSigners(t)[:threshold]
+	for _, signer := range signers {
+		signer.SetObserver(observer)
+	}
+	publicKey := signers[0].publicKey
+
+	commitments := executeRound1(t, signers)
+	signatureShares := executeRound2(t, signers, message, commitments)
+
+	testutils.AssertIntsEqual(
+		t,
+		"number of commitment generated events",
+		threshold,
+		observer.count(EventCommitmentGenerated),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"number of share generated events",
+		threshold,
+		observer.count(EventShareGenerated),
+	)
+
+	coordinator := NewCoordinator(
+		ciphersuite,
+		publicKey,
+		threshold,
+		groupSize,
+		verificationShares(signers),
+	)
+	coordinator.SetObserver(observer)
+
+	for i, signer := range signers[:2] {
+		share := signatureShares[i]
+		if i == 1 {
+			share = new(big.Int).Add(share, big.NewInt(1))
+		}
+		_, _ = coordinator.VerifySignatureShare(
+			signer.signerIndex,
+			share,
+			commitments,
+			message,
+		)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"number of share received events",
+		1,
+		observer.count(EventShareReceived),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"number of share rejected events",
+		1,
+		observer.count(EventShareRejected),
+	)
+
+	rejected := observer.events[len(observer.events)-1]
+	testutils.AssertUintsEqual(t, "rejected signer", 2, rejected.SignerIndex)
+	if rejected.Reason == nil {
+		t.Error("expected non-nil rejection reason")
+	}
+	if rejected.Time.IsZero() {
+		t.Error("expected event time to be set")
+	}
+
+	// The signature aggregated from an invalid share is not reported.
+	invalidShares := slices.Clone(signatureShares)
+	invalidShares[1] = new(big.Int).Add(invalidShares[1], big.NewInt(1))
+	_, err := coordinator.Aggregate(message, commitments, invalidShares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertIntsEqual(
+		t,
+		"number of signature produced events",
+		0,
+		observer.count(EventSignatureProduced),
+	)
+
+	_, err = coordinator.Aggregate(message, commitments, signatureShares)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"number of signature produced events",
+		1,
+		observer.count(EventSignatureProduced),
+	)
+}
@@ -11,7 +11,10 @@ import (
 type Participant struct {
 	ciphersuite Ciphersuite
 
-	publicKey *Point // group_public_key in [FROST]
+	publicKey *Point    // group_public_key in [FROST]
+	tweak     *keyTweak // nil if the group public key is not tweaked
+
+	observer Observer
 }
 
 // NonceCommitment is a message produced in Round One of [FROST].
@@ -21,6 +24,36 @@ type NonceCommitment struct {
 	bindingNonceCommitment *Point
 }
 
+// NewNonceCommitment creates a new NonceCommitment instance from the provided
+// signer identifier and commitment points. The function does not validate the
+// points; validation is performed when the commitment is used in the protocol.
+func NewNonceCommitment(
+	signerIndex uint64,
+	hidingNonceCommitment *Point,
+	bindingNonceCommitment *Point,
+) *NonceCommitment {
+	return &NonceCommitment{
+		signerIndex:            signerIndex,
+		hidingNonceCommitment:  hidingNonceCommitment,
+		bindingNonceCommitment: bindingNonceCommitment,
+	}
+}
+
+// SignerIndex returns the identifier of the signer who produced the commitment.
+func (nc *NonceCommitment) SignerIndex() uint64 {
+	return nc.signerIndex
+}
+
+// HidingNonceCommitment returns the hiding nonce commitment point.
+func (nc *NonceCommitment) HidingNonceCommitment() *Point {
+	return nc.hidingNonceCommitment
+}
+
+// BindingNonceCommitment returns the binding nonce commitment point.
+func (nc *NonceCommitment) BindingNonceCommitment() *Point {
+	return nc.bindingNonceCommitment
+}
+
 // bindingFactors is a helper structure produced by computeBindingFactors function.
 type bindingFactors map[uint64]*big.Int
 
@@ -156,8 +189,11 @@ func (p *Participant) computeBindingFactors(
 	//       representing the binding factors.
 
 	// group_public_key_enc = G.SerializeElement(group_public_key)
+	//
+	// The signature is produced for the tweaked public key if any tweak was
+	// applied so the tweaked public key is bound to the signing as well.
 	curve := p.ciphersuite.Curve()
-	groupPublicKeyEncoded := curve.SerializePoint(p.publicKey)
+	groupPublicKeyEncoded := curve.SerializePoint(p.signingKey())
 
 	// msg_hash = H4(msg)
 	msgHash := p.ciphersuite.H4(message)
@@ -254,6 +290,14 @@ func (p *Participant) computeGroupCommitment(
 	return groupCommitment
 }
 
+// hasOddY returns true if the ciphersuite requires points with an even Y
+// coordinate and the given point has an odd one. Such a point is negated
+// before it is used in the signature so every contribution to it must be
+// negated as well.
+func (p *Participant) hasOddY(point *Point) bool {
+	return p.ciphersuite.RequiresEvenY() && point.Y.Bit(0) != 0
+}
+
 // encodeGroupCommitment implements def encode_group_commitment_list(commitment_list)
 // function from [FROST], as defined in section 4.3. List Operations.
 //
@@ -369,11 +413,14 @@ func (p *Participant) deriveInterpolatingValue(xi uint64, L []uint64) *big.Int {
 			// if x_j == x_i: continue
 			continue
 		}
+		// Identifiers are converted without a cast to int64 so that
+		// identifiers greater than math.MaxInt64 do not overflow.
+		bigXj := new(big.Int).SetUint64(xj)
 		// numerator *= x_j
-		num.Mul(num, big.NewInt(int64(xj)))
+		num.Mul(num, bigXj)
 		num.Mod(num, order)
 		// denominator *= x_j - x_i
-		den.Mul(den, big.NewInt(int64(xj)-int64(xi)))
+		den.Mul(den, new(big.Int).Sub(bigXj, new(big.Int).SetUint64(xi)))
 		den.Mod(den, order)
 	}
 
@@ -415,7 +462,10 @@ func (p *Participant) computeChallenge(
 	// group_comm_enc = G.SerializeElement(group_commitment)
 	groupCommitmentEncoded := p.ciphersuite.EncodePoint(groupCommitment)
 	// group_public_key_enc = G.SerializeElement(group_public_key)
-	publicKeyEncoded := p.ciphersuite.EncodePoint(p.publicKey)
+	//
+	// The signature is verified against the tweaked public key if any tweak
+	// was applied.
+	publicKeyEncoded := p.ciphersuite.EncodePoint(p.signingKey())
 	// challenge_input = group_comm_enc || group_public_key_enc || msg
 	// challenge = H2(challenge_input)
 	// return challenge
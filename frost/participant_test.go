@@ -3,6 +3,7 @@ package frost
 import (
 	"encoding/hex"
 	"fmt"
+	"math"
 	"math/big"
 	"testing"
 
@@ -12,7 +13,7 @@ import (
 func TestValidateGroupCommitmentsBase(t *testing.T) {
 	// happy path
 	signers := createSigners(t)
-	_, commitments := executeRound1(t, signers)
+	commitments := executeRound1(t, signers)
 
 	participant := &Participant{
 		ciphersuite: signers[0].ciphersuite,
@@ -124,7 +125,7 @@ func TestValidateGroupCommitmentsBase_Errors(t *testing.T) {
 	for testName, test := range tests {
 		t.Run(testName, func(t *testing.T) {
 			signers := createSigners(t)
-			_, commitments := executeRound1(t, signers)
+			commitments := executeRound1(t, signers)
 
 			participant := &Participant{
 				ciphersuite: signers[0].ciphersuite,
@@ -273,6 +274,28 @@ func TestDeriveInterpolatingValue(t *testing.T) {
 			L:        []uint64{1, 4, 5},
 			expected: "1",
 		},
+		// Identifiers greater than math.MaxInt64 must not overflow.
+		//
+		//       (x-(2^64-1))
+		// l_0 = ------------
+		//       (1-(2^64-1))
+		//
+		// l_0 = (2^64-1)/(2^64-2) (mod Q).
+		"xi = 1, L = {1, 2^64-1}": {
+			xi:       1,
+			L:        []uint64{1, math.MaxUint64},
+			expected: "32963327279091249229957136199111461944118424535642979901165309360993334709824",
+		},
+		//       (x-1)
+		// l_1 = ------------
+		//       ((2^64-1)-1)
+		//
+		// l_1 = 1/(1-(2^64-1)) (mod Q).
+		"xi = 2^64-1, L = {1, 2^64-1}": {
+			xi:       math.MaxUint64,
+			L:        []uint64{1, math.MaxUint64},
+			expected: "82828761958224946193613848809576445908719139743431924481439853780524826784514",
+		},
 	}
 
 	participant := &Participant{
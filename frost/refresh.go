@@ -0,0 +1,124 @@
+package frost
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// NewRefreshParticipant creates a new participant of the proactive secret
+// share refresh of an existing group, as described in [HJKY95] section 4.
+// Proactive Secret Sharing. The refresh replaces the secret key shares of all
+// members with new shares of the same group secret key, so that shares
+// collected by an attacker before the refresh can not be combined with
+// shares collected after it. The group public key stays the same.
+//
+// The refresh executes the same rounds as the distributed key generation,
+// with the only difference that every participant deals a polynomial with
+// the zero constant term, δ_i(0) = 0. Every participant adds the sub-shares
+// received from the qualified participants to the current secret key share
+// and the commitments of the qualified participants to the current group VSS
+// commitment. The secret polynomials are not accompanied by proofs of
+// knowledge; instead, every participant verifies the constant term
+// commitment of every other participant is the identity element.
+//
+// The function verifies the current key share against the current group VSS
+// commitment. The threshold is the length of the VSS commitment. Once all
+// members finalized the refresh, the previous key share must be erased.
+//
+// [HJKY95]
+//
+//	Herzberg A., Jarecki S., Krawczyk H., Yung M., "Proactive Secret Sharing
+//	Or: How to Cope With Perpetual Leakage",
+//	<https://link.springer.com/chapter/10.1007/3-540-44750-4_27>
+func NewRefreshParticipant(
+	ciphersuite Ciphersuite,
+	keyShare *KeyShare,
+	groupSize int,
+	vssCommitment []*Point,
+	context []byte,
+) (*DKGParticipant, error) {
+	if keyShare == nil {
+		return nil, fmt.Errorf("key share is nil")
+	}
+
+	_, err := verifySecretKeyShare(
+		ciphersuite.Curve(),
+		keyShare.SignerIndex,
+		groupSize,
+		keyShare.SecretKeyShare,
+		vssCommitment,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key share: [%v]", err)
+	}
+
+	participant, err := NewDKGParticipant(
+		ciphersuite,
+		keyShare.SignerIndex,
+		len(vssCommitment),
+		groupSize,
+		context,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	participant.refreshedShare = new(big.Int).Set(keyShare.SecretKeyShare)
+	participant.refreshedCommitment = slices.Clone(vssCommitment)
+
+	return participant, nil
+}
+
+// isRefresh returns true if the participant refreshes the shares of an
+// existing group instead of generating a new key.
+func (dp *DKGParticipant) isRefresh() bool {
+	return dp.refreshedCommitment != nil
+}
+
+// verifyRefreshCommitment verifies the commitment broadcast by another
+// participant of the refresh. The commitment to the constant term must be
+// the identity element so that the refresh does not change the group secret
+// key. All other elements must be valid, non-identity points on the curve.
+func (dp *DKGParticipant) verifyRefreshCommitment(p *DKGRound1Package) error {
+	curve := dp.ciphersuite.Curve()
+
+	if len(p.Commitment) != dp.threshold {
+		return fmt.Errorf(
+			"commitment of participant [%d] has [%d] elements; expected [%d]",
+			p.ParticipantIndex,
+			len(p.Commitment),
+			dp.threshold,
+		)
+	}
+
+	constantTerm := p.Commitment[0]
+	if constantTerm == nil || constantTerm.X == nil || constantTerm.Y == nil ||
+		!isPointEqual(constantTerm, curve.Identity()) {
+		return fmt.Errorf(
+			"commitment of participant [%d] has a non-zero constant term",
+			p.ParticipantIndex,
+		)
+	}
+
+	for j, commitment := range p.Commitment[1:] {
+		if commitment == nil || commitment.X == nil || commitment.Y == nil ||
+			!curve.IsPointOnCurve(commitment) {
+			return fmt.Errorf(
+				"invalid commitment of participant [%d]: [VSS commitment "+
+					"element [%d] is not a valid non-identity point on the curve]",
+				p.ParticipantIndex,
+				j+1,
+			)
+		}
+	}
+
+	if p.ProofOfKnowledge != nil {
+		return fmt.Errorf(
+			"unexpected proof of knowledge from participant [%d]",
+			p.ParticipantIndex,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,396 @@
+package frost
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"threshold.network/roast/internal/testutils"
+)
+
+var refreshContext = []byte("refresh session 1")
+
+func TestRefresh(t *testing.T) {
+	curve := ciphersuite.Curve()
+	message := []byte("Even the smallest person can change the course of the future")
+	secretKey := generateSecretKey(t, true)
+
+	dealerOutput, err := TrustedDealerKeygen(ciphersuite, secretKey, 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyShares := dealerOutput.KeyShares
+	vssCommitment := dealerOutput.VSSCommitment
+
+	// Refresh twice; every refresh starts from the output of the previous
+	// one.
+	for refresh := 0; refresh < 2; refresh++ {
+		participants := createRefreshParticipants(t, keyShares, 5, vssCommitment)
+		round1Packages := executeDKGRound1(t, participants)
+		round2Packages := executeDKGRound2(t, participants, round1Packages)
+		outputs := executeDKGFinalize(t, participants, round2Packages)
+
+		for i, output := range outputs {
+			testutils.AssertBytesEqual(
+				t,
+				curve.SerializePoint(dealerOutput.PublicKey),
+				curve.SerializePoint(output.PublicKey),
+			)
+			testutils.AssertUintsEqual(
+				t,
+				"signer index",
+				keyShares[i].SignerIndex,
+				output.KeyShare.SignerIndex,
+			)
+
+			if output.KeyShare.SecretKeyShare.Cmp(keyShares[i].SecretKeyShare) == 0 {
+				t.Errorf("key share [%d] was not refreshed", i)
+			}
+			if !VerifyKeyShare(curve, output.KeyShare, output.VSSCommitment) {
+				t.Errorf("refreshed key share [%d] is not valid", i)
+			}
+			if VerifyKeyShare(curve, keyShares[i], output.VSSCommitment) {
+				t.Errorf("previous key share [%d] is still valid", i)
+			}
+
+			for j, verificationShare := range outputs[0].VerificationShares {
+				testutils.AssertBytesEqual(
+					t,
+					curve.SerializePoint(verificationShare),
+					curve.SerializePoint(output.VerificationShares[j]),
+				)
+			}
+			testutils.AssertBytesEqual(
+				t,
+				curve.SerializePoint(curve.EcBaseMul(output.KeyShare.SecretKeyShare)),
+				curve.SerializePoint(output.VerificationShares[output.KeyShare.SignerIndex]),
+			)
+		}
+
+		refreshedShares := make([]*KeyShare, len(outputs))
+		for i, output := range outputs {
+			refreshedShares[i] = output.KeyShare
+		}
+
+		// Refreshed shares interpolate to the same secret key but can not be
+		// combined with the previous shares.
+		testutils.AssertBigIntsEqual(
+			t,
+			"interpolated secret key",
+			secretKey,
+			interpolateKeyShares(
+				refreshedShares[0],
+				refreshedShares[2],
+				refreshedShares[4],
+			),
+		)
+		if interpolateKeyShares(
+			keyShares[0],
+			refreshedShares[2],
+			refreshedShares[4],
+		).Cmp(secretKey) == 0 {
+			t.Errorf("previous and refreshed shares interpolate to the secret key")
+		}
+
+		keyShares = refreshedShares
+		vssCommitment = outputs[0].VSSCommitment
+	}
+
+	signers := make([]*Signer, 3)
+	verificationShares := make(map[uint64]*Point)
+	for i := range signers {
+		keyShare := keyShares[i+1]
+		signer, verificationShare, err := NewVerifiedSigner(
+			ciphersuite,
+			keyShare.SignerIndex,
+			5,
+			keyShare.SecretKeyShare,
+			vssCommitment,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signers[i] = signer
+		verificationShares[keyShare.SignerIndex] = verificationShare
+	}
+
+	coordinator := NewCoordinator(
+		ciphersuite,
+		dealerOutput.PublicKey,
+		3,
+		5,
+		verificationShares,
+	)
+
+	commitments := executeRound1(t, signers)
+	signatureShares := executeRound2(t, signers, message, commitments)
+
+	signature, err := coordinator.AggregateIdentifiable(
+		message,
+		commitments,
+		signatureShares,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid, err := ciphersuite.VerifySignature(signature, dealerOutput.PublicKey, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "signature validity", true, valid)
+}
+
+func TestRefresh_DisqualifiedDealer(t *testing.T) {
+	curve := ciphersuite.Curve()
+	secretKey := generateSecretKey(t, false)
+
+	dealerOutput, err := TrustedDealerKeygen(ciphersuite, secretKey, 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	participants := createRefreshParticipants(
+		t,
+		dealerOutput.KeyShares,
+		5,
+		dealerOutput.VSSCommitment,
+	)
+	round1Packages := executeDKGRound1(t, participants)
+	round2Packages := executeDKGRound2(t, participants, round1Packages)
+
+	// The fourth participant sends an invalid sub-share to the first one and
+	// does not answer the complaint.
+	for _, p := range round2Packages[3] {
+		if p.ReceiverIndex == 1 {
+			p.SecretShare = new(big.Int).Add(p.SecretShare, big.NewInt(1))
+		}
+	}
+
+	complaints := executeDKGVerifyShares(t, participants, round2Packages)
+	justifications := slices.DeleteFunc(
+		executeDKGJustify(t, participants, complaints),
+		func(j *DKGJustification) bool {
+			return j.DealerIndex == 4
+		},
+	)
+	outputs := finalizeDKG(t, participants, complaints, justifications)
+
+	for _, output := range outputs {
+		testutils.AssertIntsEqual(
+			t,
+			"number of qualified participants",
+			4,
+			len(output.QualifiedParticipants),
+		)
+		testutils.AssertBytesEqual(
+			t,
+			curve.SerializePoint(dealerOutput.PublicKey),
+			curve.SerializePoint(output.PublicKey),
+		)
+		if !VerifyKeyShare(curve, output.KeyShare, output.VSSCommitment) {
+			t.Errorf(
+				"refreshed key share of participant [%d] is not valid",
+				output.KeyShare.SignerIndex,
+			)
+		}
+	}
+
+	testutils.AssertBigIntsEqual(
+		t,
+		"interpolated secret key",
+		secretKey,
+		interpolateKeyShares(
+			outputs[0].KeyShare,
+			outputs[3].KeyShare,
+			outputs[4].KeyShare,
+		),
+	)
+}
+
+func TestRefreshRound2_Failures(t *testing.T) {
+	curve := ciphersuite.Curve()
+
+	tests := map[string]struct {
+		modify      func([]*DKGRound1Package)
+		expectedErr string
+	}{
+		"non-zero constant term": {
+			modify: func(p []*DKGRound1Package) {
+				p[0].Commitment[0] = curve.EcBaseMul(big.NewInt(1))
+			},
+			expectedErr: "commitment of participant [2] has a non-zero constant term",
+		},
+		"nil constant term": {
+			modify: func(p []*DKGRound1Package) {
+				p[0].Commitment[0] = nil
+			},
+			expectedErr: "commitment of participant [2] has a non-zero constant term",
+		},
+		"identity element": {
+			modify: func(p []*DKGRound1Package) {
+				p[1].Commitment[2] = curve.Identity()
+			},
+			expectedErr: "invalid commitment of participant [3]: [VSS commitment " +
+				"element [2] is not a valid non-identity point on the curve]",
+		},
+		"commitment of wrong length": {
+			modify: func(p []*DKGRound1Package) {
+				p[2].Commitment = p[2].Commitment[:2]
+			},
+			expectedErr: "commitment of participant [4] has [2] elements; " +
+				"expected [3]",
+		},
+		"proof of knowledge": {
+			modify: func(p []*DKGRound1Package) {
+				p[3].ProofOfKnowledge = &Signature{}
+			},
+			expectedErr: "unexpected proof of knowledge from participant [5]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			dealerOutput, err := TrustedDealerKeygen(ciphersuite, big.NewInt(42), 3, 5)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			participants := createRefreshParticipants(
+				t,
+				dealerOutput.KeyShares,
+				5,
+				dealerOutput.VSSCommitment,
+			)
+			round1Packages := executeDKGRound1(t, participants)
+
+			packages := make([]*DKGRound1Package, 0, 4)
+			for _, p := range round1Packages[1:] {
+				packages = append(packages, &DKGRound1Package{
+					ParticipantIndex: p.ParticipantIndex,
+					Commitment:       slices.Clone(p.Commitment),
+				})
+			}
+			test.modify(packages)
+
+			_, err = participants[0].Round2(packages)
+
+			var dkgErr *DKGError
+			if !errors.As(err, &dkgErr) {
+				t.Fatalf("expected DKG error; has [%v]", err)
+			}
+			testutils.AssertIntsEqual(t, "number of causes", 1, len(dkgErr.Causes))
+			testutils.AssertStringsEqual(
+				t,
+				"round two error",
+				test.expectedErr,
+				dkgErr.Causes[0].Error(),
+			)
+		})
+	}
+}
+
+func TestNewRefreshParticipant_Failures(t *testing.T) {
+	dealerOutput, err := TrustedDealerKeygen(ciphersuite, big.NewInt(42), 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyShare := dealerOutput.KeyShares[1]
+
+	tests := map[string]struct {
+		keyShare      *KeyShare
+		vssCommitment []*Point
+		expectedErr   string
+	}{
+		"nil key share": {
+			keyShare:      nil,
+			vssCommitment: dealerOutput.VSSCommitment,
+			expectedErr:   "key share is nil",
+		},
+		"corrupted key share": {
+			keyShare: &KeyShare{
+				SignerIndex: keyShare.SignerIndex,
+				SecretKeyShare: new(big.Int).Add(
+					keyShare.SecretKeyShare,
+					big.NewInt(1),
+				),
+			},
+			vssCommitment: dealerOutput.VSSCommitment,
+			expectedErr: "invalid key share: [secret key share of signer [2] " +
+				"does not match the VSS commitment]",
+		},
+		"truncated VSS commitment": {
+			keyShare:      keyShare,
+			vssCommitment: dealerOutput.VSSCommitment[:1],
+			expectedErr: "invalid key share: [VSS commitment must have at " +
+				"least [2] elements; has [1]]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, err := NewRefreshParticipant(
+				ciphersuite,
+				test.keyShare,
+				5,
+				test.vssCommitment,
+				refreshContext,
+			)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			testutils.AssertStringsEqual(
+				t,
+				"refresh participant error",
+				test.expectedErr,
+				err.Error(),
+			)
+		})
+	}
+}
+
+func createRefreshParticipants(
+	t *testing.T,
+	keyShares []*KeyShare,
+	groupSize int,
+	vssCommitment []*Point,
+) []*DKGParticipant {
+	participants := make([]*DKGParticipant, len(keyShares))
+	for i, keyShare := range keyShares {
+		participant, err := NewRefreshParticipant(
+			ciphersuite,
+			keyShare,
+			groupSize,
+			vssCommitment,
+			refreshContext,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		participants[i] = participant
+	}
+	return participants
+}
+
+// interpolateKeyShares returns the secret interpolated from the given key
+// shares.
+func interpolateKeyShares(keyShares ...*KeyShare) *big.Int {
+	participant := &Participant{ciphersuite: ciphersuite}
+
+	L := make([]uint64, len(keyShares))
+	for i, keyShare := range keyShares {
+		L[i] = keyShare.SignerIndex
+	}
+
+	secret := big.NewInt(0)
+	for _, keyShare := range keyShares {
+		term := new(big.Int).Mul(
+			participant.deriveInterpolatingValue(keyShare.SignerIndex, L),
+			keyShare.SecretKeyShare,
+		)
+		secret.Add(secret, term)
+	}
+	return secret.Mod(secret, ciphersuite.Curve().Order())
+}
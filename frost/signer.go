@@ -1,6 +1,7 @@
 package frost
 
 import (
+	"context"
 	"crypto/rand"
 	"errors"
 	"fmt"
@@ -12,7 +13,11 @@ type Signer struct {
 	Participant
 
 	signerIndex    uint64   // i in [FROST]
+	threshold      int      // MIN_PARTICIPANTS in [FROST]
+	groupSize      int      // MAX_PARTICIPANTS in [FROST]
 	secretKeyShare *big.Int // sk_i in [FROST]
+
+	nonces NonceStore // nonces generated in Round One, until used in Round Two
 }
 
 // Nonce is a message produced in Round One of [FROST].
@@ -21,12 +26,61 @@ type Nonce struct {
 	bindingNonce *big.Int
 }
 
-// NewSigner creates a new [FROST] Signer instance.
+// NewNonce creates a new Nonce instance from the provided hiding and binding
+// nonces. The function is meant to restore a nonce previously generated in
+// Round One, for example, from a persistent storage by a NonceStore
+// implementation.
+func NewNonce(hidingNonce *big.Int, bindingNonce *big.Int) *Nonce {
+	return &Nonce{
+		hidingNonce:  hidingNonce,
+		bindingNonce: bindingNonce,
+	}
+}
+
+// HidingNonce returns the hiding nonce.
+func (n *Nonce) HidingNonce() *big.Int {
+	return n.hidingNonce
+}
+
+// BindingNonce returns the binding nonce.
+func (n *Nonce) BindingNonce() *big.Int {
+	return n.bindingNonce
+}
+
+// NewSigner creates a new [FROST] Signer instance keeping nonces in
+// a MemoryNonceStore. The threshold and the group size are the minimum and
+// the maximum number of signers taking part in the signing. Members of the
+// group are identified with 1, 2, ..., groupSize.
 func NewSigner(
 	ciphersuite Ciphersuite,
 	signerIndex uint64,
 	publicKey *Point,
+	threshold int,
+	groupSize int,
 	secretKeyShare *big.Int,
+) *Signer {
+	return NewSignerWithNonceStore(
+		ciphersuite,
+		signerIndex,
+		publicKey,
+		threshold,
+		groupSize,
+		secretKeyShare,
+		NewMemoryNonceStore(),
+	)
+}
+
+// NewSignerWithNonceStore creates a new [FROST] Signer instance keeping
+// nonces generated in Round One in the given store until they are used in
+// Round Two.
+func NewSignerWithNonceStore(
+	ciphersuite Ciphersuite,
+	signerIndex uint64,
+	publicKey *Point,
+	threshold int,
+	groupSize int,
+	secretKeyShare *big.Int,
+	nonces NonceStore,
 ) *Signer {
 	return &Signer{
 		Participant: Participant{
@@ -34,13 +88,82 @@ func NewSigner(
 			publicKey:   publicKey,
 		},
 		signerIndex:    signerIndex,
+		threshold:      threshold,
+		groupSize:      groupSize,
 		secretKeyShare: secretKeyShare,
+		nonces:         nonces,
+	}
+}
+
+// NewVerifiedSigner creates a new [FROST] Signer instance keeping nonces in
+// a MemoryNonceStore, after verifying the secret key share against the VSS
+// commitment of the group, as produced by TrustedDealerKeygen or the
+// distributed key generation. The threshold is the length of the VSS
+// commitment and the group public key is its first element.
+//
+// The function returns the signer along with the signer's verification
+// share derived from the VSS commitment. The function fails if the secret
+// key share does not match the VSS commitment, so a corrupted share is
+// detected before any signing takes place.
+func NewVerifiedSigner(
+	ciphersuite Ciphersuite,
+	signerIndex uint64,
+	groupSize int,
+	secretKeyShare *big.Int,
+	vssCommitment []*Point,
+) (*Signer, *Point, error) {
+	return NewVerifiedSignerWithNonceStore(
+		ciphersuite,
+		signerIndex,
+		groupSize,
+		secretKeyShare,
+		vssCommitment,
+		NewMemoryNonceStore(),
+	)
+}
+
+// NewVerifiedSignerWithNonceStore is NewVerifiedSigner keeping nonces
+// generated in Round One in the given store until they are used in Round
+// Two.
+func NewVerifiedSignerWithNonceStore(
+	ciphersuite Ciphersuite,
+	signerIndex uint64,
+	groupSize int,
+	secretKeyShare *big.Int,
+	vssCommitment []*Point,
+	nonces NonceStore,
+) (*Signer, *Point, error) {
+	verificationShare, err := verifySecretKeyShare(
+		ciphersuite.Curve(),
+		signerIndex,
+		groupSize,
+		secretKeyShare,
+		vssCommitment,
+	)
+	if err != nil {
+		return nil, nil, err
 	}
+
+	signer := NewSignerWithNonceStore(
+		ciphersuite,
+		signerIndex,
+		vssCommitment[0],
+		len(vssCommitment),
+		groupSize,
+		secretKeyShare,
+		nonces,
+	)
+
+	return signer, verificationShare, nil
 }
 
 // Round1 implements the Round One - Commitment phase from [FROST], section
 // 5.1. Round One - Commitment.
-func (s *Signer) Round1() (*Nonce, *NonceCommitment, error) {
+//
+// The generated nonce is kept in the signer's NonceStore and never leaves the
+// signer. The function returns just the nonce commitment. The commitment
+// should be passed to Round2 to produce the signature share with the nonce.
+func (s *Signer) Round1() (*NonceCommitment, error) {
 	//	From [FROST]:
 	//
 	//	5.1. Round One - Commitment
@@ -66,12 +189,12 @@ func (s *Signer) Round1() (*Nonce, *NonceCommitment, error) {
 	// hiding_nonce = nonce_generate(sk_i)
 	hn, err := s.generateNonce(s.secretKeyShare.Bytes())
 	if err != nil {
-		return nil, nil, fmt.Errorf("hiding nonce generation failed: [%v]", err)
+		return nil, fmt.Errorf("hiding nonce generation failed: [%v]", err)
 	}
 	// binding_nonce = nonce_generate(sk_i)
 	bn, err := s.generateNonce(s.secretKeyShare.Bytes())
 	if err != nil {
-		return nil, nil, fmt.Errorf("binding nonce generation failed: [%v]", err)
+		return nil, fmt.Errorf("binding nonce generation failed: [%v]", err)
 	}
 
 	// hiding_nonce_commitment = G.ScalarBaseMult(hiding_nonce)
@@ -82,7 +205,42 @@ func (s *Signer) Round1() (*Nonce, *NonceCommitment, error) {
 	// nonces = (hiding_nonce, binding_nonce)
 	// comms = (hiding_nonce_commitment, binding_nonce_commitment)
 	// return (nonces, comms)
-	return &Nonce{hn, bn}, &NonceCommitment{s.signerIndex, hnc, bnc}, nil
+	nonce := &Nonce{hn, bn}
+	commitment := &NonceCommitment{s.signerIndex, hnc, bnc}
+
+	if err := s.nonces.Put(commitment, nonce); err != nil {
+		return nil, fmt.Errorf("could not store nonce: [%v]", err)
+	}
+
+	s.notify(&Event{
+		Kind:        EventCommitmentGenerated,
+		SignerIndex: s.signerIndex,
+	})
+
+	return commitment, nil
+}
+
+// Round1Batch executes Round One count times and returns all generated
+// nonce commitments. As [FROST] section 5.1. Round One - Commitment allows,
+// the commitments can be generated in a pre-processing stage and published
+// to the coordinator ahead of signing so that the signing requires just
+// Round Two. Nonces are kept in the signer's NonceStore, each until its
+// commitment is used in Round2.
+func (s *Signer) Round1Batch(count int) ([]*NonceCommitment, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("count must be positive; has [%d]", count)
+	}
+
+	commitments := make([]*NonceCommitment, count)
+	for i := range commitments {
+		commitment, err := s.Round1()
+		if err != nil {
+			return nil, fmt.Errorf("commitment [%d]: [%v]", i, err)
+		}
+		commitments[i] = commitment
+	}
+
+	return commitments, nil
 }
 
 func (s *Signer) generateNonce(secret []byte) (*big.Int, error) {
@@ -100,49 +258,187 @@ func (s *Signer) generateNonce(secret []byte) (*big.Int, error) {
 
 // Round2 implements the Round Two - Signature Share Generation phase from
 // [FROST], section 5.2 Round Two - Signature Share Generation.
+//
+// The commitment is this signer's commitment returned from Round1 and it must
+// be on the commitment list. The nonce for the commitment is taken from the
+// signer's NonceStore, and so deleted, before the signature share is
+// returned. The nonce is not taken if the function fails before the
+// signature share computation, for example, because of an invalid
+// commitment list. Once taken, the nonce can not be used again and Round2
+// for the same commitment fails.
 func (s *Signer) Round2(
 	message []byte,
-	nonce *Nonce,
+	commitment *NonceCommitment,
 	commitments []*NonceCommitment,
 ) (*big.Int, error) {
-	// TODO: validate the number of commitments
+	return s.Round2Context(context.Background(), message, commitment, commitments)
+}
 
+// Round2Context is like Round2 but stops with the context error if the
+// context is done before the signature share is computed. The context is
+// checked between the steps iterating over the commitment list so that
+// Round Two for a large group can be interrupted. The nonce is not taken if
+// the context is done.
+func (s *Signer) Round2Context(
+	ctx context.Context,
+	message []byte,
+	commitment *NonceCommitment,
+	commitments []*NonceCommitment,
+) (*big.Int, error) {
 	// participant_list = participants_from_commitment_list(commitment_list)
 	validationErrors, participants := s.validateGroupCommitments(commitments)
 	if len(validationErrors) != 0 {
 		return nil, errors.Join(validationErrors...)
 	}
 
+	if err := s.validateOwnCommitment(commitment, commitments); err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// binding_factor_list = compute_binding_factors(group_public_key, commitment_list, msg)
 	bindingFactors := s.computeBindingFactors(message, commitments)
 	// binding_factor = binding_factor_for_participant(binding_factor_list, identifier)
 	bindingFactor := bindingFactors[s.signerIndex]
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// group_commitment = compute_group_commitment(commitment_list, binding_factor_list)
 	groupCommitment := s.computeGroupCommitment(commitments, bindingFactors)
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// lambda_i = derive_interpolating_value(participant_list, identifier)
 	lambda := s.deriveInterpolatingValue(s.signerIndex, participants)
 
 	// challenge = compute_challenge(group_commitment, group_public_key, msg)
 	challenge := s.computeChallenge(message, groupCommitment)
 
-	bnbf := new(big.Int).Mul(nonce.bindingNonce, bindingFactor) // (binding_nonce * binding_factor)
-	lski := new(big.Int).Mul(lambda, s.secretKeyShare)          // lambda_i * sk_i
-	lskic := new(big.Int).Mul(lski, challenge)                  // (lambda_i * sk_i * challenge)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// The nonce is taken as the last step before the signature share
+	// computation that can not fail. This way, the nonce is deleted before
+	// the share is returned but is not lost if Round Two fails earlier.
+	nonce, err := s.nonces.Take(commitment)
+	if err != nil {
+		return nil, fmt.Errorf("could not take nonce for the commitment: [%v]", err)
+	}
+
+	order := s.ciphersuite.Curve().Order()
+
+	// If the ciphersuite requires the even Y coordinate of the group
+	// commitment and it is odd, the group commitment is negated in the
+	// signature. The group commitment is the sum of the nonce commitments, so
+	// every signer negates its nonces to produce a share for the negated
+	// group commitment.
+	hidingNonce := nonce.hidingNonce
+	bindingNonce := nonce.bindingNonce
+	if s.hasOddY(groupCommitment) {
+		hidingNonce = new(big.Int).Sub(order, hidingNonce)
+		bindingNonce = new(big.Int).Sub(order, bindingNonce)
+	}
+
+	// The same applies to the group public key, being the interpolation of
+	// the public keys of all signers, and to the tweaked public key. The
+	// group secret key is negated by negating all secret key shares. The
+	// tweak itself is added to the signature by the coordinator.
+	secretKeyShare := s.secretKeyShare
+	if s.negatesKeyShare() {
+		secretKeyShare = new(big.Int).Sub(order, secretKeyShare)
+	}
+
+	bnbf := new(big.Int).Mul(bindingNonce, bindingFactor) // (binding_nonce * binding_factor)
+	lski := new(big.Int).Mul(lambda, secretKeyShare)      // lambda_i * sk_i
+	lskic := new(big.Int).Mul(lski, challenge)            // (lambda_i * sk_i * challenge)
 
 	// sig_share = hiding_nonce + (binding_nonce * binding_factor) + (lambda_i * sk_i * challenge)
 	sigShare := new(big.Int).Add(
-		nonce.hidingNonce,
+		hidingNonce,
 		new(big.Int).Add(bnbf, lskic),
 	)
+	// sig_share is a Scalar so it must be reduced modulo the group order
+	sigShare.Mod(sigShare, order)
+
+	s.notify(&Event{
+		Kind:        EventShareGenerated,
+		SignerIndex: s.signerIndex,
+	})
 
 	return sigShare, nil
 }
 
+// ValidateRound2 performs all validations of the Round2 inputs without
+// computing the signature share and without taking the nonce from the
+// NonceStore. The function returns the same error Round2 would return for
+// invalid inputs. It lets the caller signing several messages at once ensure
+// Round2 accepts the inputs for all of them before any nonce is taken.
+func (s *Signer) ValidateRound2(
+	commitment *NonceCommitment,
+	commitments []*NonceCommitment,
+) error {
+	validationErrors, _ := s.validateGroupCommitments(commitments)
+	if len(validationErrors) != 0 {
+		return errors.Join(validationErrors...)
+	}
+
+	return s.validateOwnCommitment(commitment, commitments)
+}
+
+// validateOwnCommitment checks the commitment belongs to this signer and is
+// the one on the commitment list. Otherwise, the nonce taken for the
+// commitment would not match the commitment the group commitment is computed
+// from and the signature share would be invalid.
+func (s *Signer) validateOwnCommitment(
+	commitment *NonceCommitment,
+	commitments []*NonceCommitment,
+) error {
+	if commitment == nil {
+		return fmt.Errorf("current signer's commitment is nil")
+	}
+	if commitment.signerIndex != s.signerIndex {
+		return fmt.Errorf(
+			"commitment of signer [%d] is not the current signer's commitment",
+			commitment.signerIndex,
+		)
+	}
+
+	for _, c := range commitments {
+		if c.signerIndex != s.signerIndex {
+			continue
+		}
+		if !isPointEqual(c.hidingNonceCommitment, commitment.hidingNonceCommitment) ||
+			!isPointEqual(c.bindingNonceCommitment, commitment.bindingNonceCommitment) {
+			return fmt.Errorf(
+				"current signer's commitment does not match the one on the list",
+			)
+		}
+	}
+
+	return nil
+}
+
+// isPointEqual returns true if both points have the same coordinates.
+func isPointEqual(a *Point, b *Point) bool {
+	if a == nil || b == nil || a.X == nil || a.Y == nil || b.X == nil || b.Y == nil {
+		return false
+	}
+	return a.X.Cmp(b.X) == 0 && a.Y.Cmp(b.Y) == 0
+}
+
 // validateGroupCommitments is a helper function used internally in RoundTwo
-// to validate the group commitments. Four validations are done:
+// to validate the group commitments. Six validations are done:
+// - The number of commitments is between the threshold and the group size.
 // - This signer's commitment is included in the commitments.
+// - All commitments are from members of the group.
 // - None of the commitments is a point not lying on the curve.
 // - The list of commitments is sorted in ascending order by signer identifier.
 // - None of the commitments is nil.
@@ -152,9 +448,9 @@ func (s *Signer) Round2(
 // def participants_from_commitment_list(commitment_list) function from [FROST]
 // section 4.3. List Operations.
 //
-// If this signer's commitment is not included in the commitments, the function
-// does not perform the rest of validations to not spend any more computing
-// resources.
+// If the number of commitments is invalid or this signer's commitment is not
+// included in the commitments, the function does not perform the rest of
+// validations to not spend any more computing resources.
 func (s *Signer) validateGroupCommitments(
 	commitments []*NonceCommitment,
 ) ([]error, []uint64) {
@@ -186,6 +482,38 @@ func (s *Signer) validateGroupCommitments(
 	//	 NonZeroScalar identifier i and two commitment Element values
 	//	 (hiding_nonce_commitment_i, binding_nonce_commitment_i). This list
 	//	 MUST be sorted in ascending order by identifier.
+	//
+	// 5.2. Round Two - Signature Share Generation
+	//
+	//   (...)
+	//
+	//   The Coordinator begins by sending each participant the message to be
+	//   signed along with the set of signing commitments for all
+	//   participants in the participant list. Each participant MUST validate
+	//   the inputs before processing the Coordinator's request. In
+	//   particular, the Signer MUST validate commitment_list, deserializing
+	//   each group Element in the list using DeserializeElement from
+	//   Section 3.1. If deserialization fails, the Signer MUST abort the
+	//   protocol. Moreover, each participant MUST ensure that its identifier
+	//   and commitments (from the first round) appear in commitment_list.
+
+	// MIN_PARTICIPANTS <= NUM_PARTICIPANTS
+	if len(commitments) < s.threshold {
+		return []error{fmt.Errorf(
+			"not enough commitments; has [%d] for threshold [%d]",
+			len(commitments),
+			s.threshold,
+		)}, nil
+	}
+
+	// NUM_PARTICIPANTS <= MAX_PARTICIPANTS
+	if len(commitments) > s.groupSize {
+		return []error{fmt.Errorf(
+			"too many commitments; has [%d] for group size [%d]",
+			len(commitments),
+			s.groupSize,
+		)}, nil
+	}
 
 	found := false
 	for _, c := range commitments {
@@ -201,5 +529,26 @@ func (s *Signer) validateGroupCommitments(
 		}, nil
 	}
 
-	return s.validateGroupCommitmentsBase(commitments)
+	var errs []error
+	for i, c := range commitments {
+		// Identifiers are NonZeroScalars and the signer with an identifier
+		// greater than the group size has no key share.
+		if c != nil && (c.signerIndex == 0 || c.signerIndex > uint64(s.groupSize)) {
+			errs = append(errs, fmt.Errorf(
+				"commitment at position [%d] is from signer [%d] not being "+
+					"a member of the group of size [%d]",
+				i,
+				c.signerIndex,
+				s.groupSize,
+			))
+		}
+	}
+
+	validationErrors, participants := s.validateGroupCommitmentsBase(commitments)
+	errs = append(errs, validationErrors...)
+	if len(errs) != 0 {
+		return errs, nil
+	}
+
+	return nil, participants
 }
@@ -1,7 +1,10 @@
 package frost
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"slices"
 	"testing"
@@ -12,13 +15,12 @@ import (
 func TestRound2_ValidationError(t *testing.T) {
 	// just a basic test checking if Round2 calls validateGroupCommitments
 	signers := createSigners(t)
-	nonces, commitments := executeRound1(t, signers)
+	commitments := executeRound1(t, signers)
 	commitments[0].bindingNonceCommitment = &Point{big.NewInt(99), big.NewInt(88)}
 
 	signer := signers[1]
-	nonce := nonces[1]
 
-	_, err := signer.Round2([]byte("dummy"), nonce, commitments)
+	_, err := signer.Round2([]byte("dummy"), commitments[1], commitments)
 	if err == nil {
 		t.Fatalf("expected a non-nil error")
 	}
@@ -28,10 +30,29 @@ func TestRound2_ValidationError(t *testing.T) {
 	testutils.AssertStringsEqual(t, "validation error", expectedError, err.Error())
 }
 
+func TestRound2Context_Cancelled(t *testing.T) {
+	signers := createSigners(t)
+	commitments := executeRound1(t, signers)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := signers[0].Round2Context(ctx, []byte("dummy"), commitments[0], commitments)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+
+	// The nonce is not taken if Round Two was interrupted.
+	_, err = signers[0].Round2([]byte("dummy"), commitments[0], commitments)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestValidateGroupCommitments(t *testing.T) {
 	// happy path
 	signers := createSigners(t)
-	_, commitments := executeRound1(t, signers)
+	commitments := executeRound1(t, signers)
 
 	signer := signers[0]
 
@@ -70,6 +91,35 @@ func TestValidateGroupCommitments_Errors(t *testing.T) {
 				"current signer's commitment not found on the list",
 			},
 		},
+		"not enough commitments": {
+			modifyCommitments: func(commitments []*NonceCommitment) []*NonceCommitment {
+				return commitments[:threshold-1]
+			},
+			expectedErrors: []string{
+				"not enough commitments; has [50] for threshold [51]",
+			},
+		},
+		"too many commitments": {
+			modifyCommitments: func(commitments []*NonceCommitment) []*NonceCommitment {
+				extra := *commitments[99]
+				extra.signerIndex = 101
+				return append(commitments, &extra)
+			},
+			expectedErrors: []string{
+				"too many commitments; has [101] for group size [100]",
+			},
+		},
+		"commitments from signers not being members of the group": {
+			modifyCommitments: func(commitments []*NonceCommitment) []*NonceCommitment {
+				commitments[98].signerIndex = 101
+				commitments[99].signerIndex = math.MaxUint64
+				return commitments
+			},
+			expectedErrors: []string{
+				"commitment at position [98] is from signer [101] not being a member of the group of size [100]",
+				"commitment at position [99] is from signer [18446744073709551615] not being a member of the group of size [100]",
+			},
+		},
 		// We don't want to repeat all validateGroupCommitmentsBase errors but we want
 		// to ensure this function is called and all sort of errors are detected.
 		// Better be safe than sorry.
@@ -107,7 +157,7 @@ func TestValidateGroupCommitments_Errors(t *testing.T) {
 	for testName, test := range tests {
 		t.Run(testName, func(t *testing.T) {
 			signers := createSigners(t)
-			_, commitments := executeRound1(t, signers)
+			commitments := executeRound1(t, signers)
 			signer := signers[0]
 
 			modified := test.modifyCommitments(commitments)
@@ -143,3 +193,294 @@ func TestValidateGroupCommitments_Errors(t *testing.T) {
 		})
 	}
 }
+
+func TestRound2_NonceReuse(t *testing.T) {
+	message := []byte("For even the very wise cannot see all ends")
+
+	signers := createSigners(t)[:threshold]
+	commitments := executeRound1(t, signers)
+	executeRound2(t, signers, message, commitments)
+
+	_, err := signers[0].Round2(message, commitments[0], commitments)
+	expectedErr := "could not take nonce for the commitment: [nonce not found]"
+	testutils.AssertStringsEqual(t, "nonce reuse error", expectedErr, err.Error())
+}
+
+func TestRound2_OwnCommitmentErrors(t *testing.T) {
+	message := []byte("For even the very wise cannot see all ends")
+
+	signers := createSigners(t)[:threshold]
+	commitments := executeRound1(t, signers)
+
+	signer := signers[0]
+	other, err := signer.Round1()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		commitment  *NonceCommitment
+		expectedErr string
+	}{
+		"nil commitment": {
+			commitment:  nil,
+			expectedErr: "current signer's commitment is nil",
+		},
+		"commitment of another signer": {
+			commitment:  commitments[1],
+			expectedErr: "commitment of signer [2] is not the current signer's commitment",
+		},
+		"commitment not on the list": {
+			commitment:  other,
+			expectedErr: "current signer's commitment does not match the one on the list",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, err := signer.Round2(message, test.commitment, commitments)
+			testutils.AssertStringsEqual(
+				t,
+				"round two error",
+				test.expectedErr,
+				err.Error(),
+			)
+		})
+	}
+
+	// None of the failed attempts took the nonce.
+	_, err = signer.Round2(message, commitments[0], commitments)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateRound2(t *testing.T) {
+	message := []byte("For even the very wise cannot see all ends")
+
+	signers := createSigners(t)[:threshold]
+	commitments := executeRound1(t, signers)
+
+	signer := signers[0]
+
+	err := signer.ValidateRound2(commitments[0], commitments[:threshold-1])
+	testutils.AssertStringsEqual(
+		t,
+		"validation error",
+		fmt.Sprintf(
+			"not enough commitments; has [%d] for threshold [%d]",
+			threshold-1,
+			threshold,
+		),
+		err.Error(),
+	)
+
+	err = signer.ValidateRound2(commitments[1], commitments)
+	testutils.AssertStringsEqual(
+		t,
+		"validation error",
+		"commitment of signer [2] is not the current signer's commitment",
+		err.Error(),
+	)
+
+	if err := signer.ValidateRound2(commitments[0], commitments); err != nil {
+		t.Fatal(err)
+	}
+
+	// The validation does not take the nonce.
+	_, err = signer.Round2(message, commitments[0], commitments)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRound1Batch(t *testing.T) {
+	signer := createSigners(t)[0]
+
+	commitments, err := signer.Round1Batch(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertIntsEqual(t, "number of commitments", 3, len(commitments))
+
+	// Every commitment has its own nonce in the store.
+	for i, commitment := range commitments {
+		if _, err := signer.nonces.Take(commitment); err != nil {
+			t.Fatalf("commitment [%d]: [%v]", i, err)
+		}
+	}
+
+	_, err = signer.Round1Batch(0)
+	testutils.AssertStringsEqual(
+		t,
+		"round one batch error",
+		"count must be positive; has [0]",
+		err.Error(),
+	)
+}
+
+func TestNewVerifiedSigner(t *testing.T) {
+	curve := ciphersuite.Curve()
+	message := []byte("Not all those who wander are lost")
+
+	output, err := TrustedDealerKeygen(ciphersuite, generateSecretKey(t, true), 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signers := make([]*Signer, 3)
+	for i := range signers {
+		keyShare := output.KeyShares[i+2]
+		signer, verificationShare, err := NewVerifiedSigner(
+			ciphersuite,
+			keyShare.SignerIndex,
+			5,
+			keyShare.SecretKeyShare,
+			output.VSSCommitment,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testutils.AssertBytesEqual(
+			t,
+			curve.SerializePoint(output.VerificationShares[keyShare.SignerIndex]),
+			curve.SerializePoint(verificationShare),
+		)
+		testutils.AssertIntsEqual(t, "threshold", 3, signer.threshold)
+		testutils.AssertBytesEqual(
+			t,
+			curve.SerializePoint(output.PublicKey),
+			curve.SerializePoint(signer.publicKey),
+		)
+		signers[i] = signer
+	}
+
+	coordinator := NewCoordinator(
+		ciphersuite,
+		output.PublicKey,
+		3,
+		5,
+		output.VerificationShares,
+	)
+
+	commitments := executeRound1(t, signers)
+	signatureShares := executeRound2(t, signers, message, commitments)
+
+	signature, err := coordinator.AggregateIdentifiable(
+		message,
+		commitments,
+		signatureShares,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid, err := ciphersuite.VerifySignature(signature, output.PublicKey, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "signature validity", true, valid)
+}
+
+func TestNewVerifiedSigner_Failures(t *testing.T) {
+	curve := ciphersuite.Curve()
+
+	output, err := TrustedDealerKeygen(ciphersuite, big.NewInt(42), 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyShare := output.KeyShares[1]
+
+	tests := map[string]struct {
+		signerIndex    uint64
+		groupSize      int
+		secretKeyShare *big.Int
+		vssCommitment  []*Point
+		expectedErr    string
+	}{
+		"share of another signer": {
+			signerIndex:    keyShare.SignerIndex + 1,
+			groupSize:      5,
+			secretKeyShare: keyShare.SecretKeyShare,
+			vssCommitment:  output.VSSCommitment,
+			expectedErr:    "secret key share of signer [3] does not match the VSS commitment",
+		},
+		"corrupted share": {
+			signerIndex: keyShare.SignerIndex,
+			groupSize:   5,
+			secretKeyShare: new(big.Int).Add(
+				keyShare.SecretKeyShare,
+				big.NewInt(1),
+			),
+			vssCommitment: output.VSSCommitment,
+			expectedErr:   "secret key share of signer [2] does not match the VSS commitment",
+		},
+		"share of another group": {
+			signerIndex:    keyShare.SignerIndex,
+			groupSize:      5,
+			secretKeyShare: keyShare.SecretKeyShare,
+			vssCommitment: []*Point{
+				curve.EcBaseMul(big.NewInt(43)),
+				output.VSSCommitment[1],
+				output.VSSCommitment[2],
+			},
+			expectedErr: "secret key share of signer [2] does not match the VSS commitment",
+		},
+		"nil share": {
+			signerIndex:    keyShare.SignerIndex,
+			groupSize:      5,
+			secretKeyShare: nil,
+			vssCommitment:  output.VSSCommitment,
+			expectedErr:    "secret key share is not a valid non-zero scalar",
+		},
+		"zero signer identifier": {
+			signerIndex:    0,
+			groupSize:      5,
+			secretKeyShare: keyShare.SecretKeyShare,
+			vssCommitment:  output.VSSCommitment,
+			expectedErr:    "signer [0] is not a member of the group of size [5]",
+		},
+		"signer identifier greater than the group size": {
+			signerIndex:    6,
+			groupSize:      5,
+			secretKeyShare: keyShare.SecretKeyShare,
+			vssCommitment:  output.VSSCommitment,
+			expectedErr:    "signer [6] is not a member of the group of size [5]",
+		},
+		"threshold greater than the group size": {
+			signerIndex:    keyShare.SignerIndex,
+			groupSize:      2,
+			secretKeyShare: keyShare.SecretKeyShare,
+			vssCommitment:  output.VSSCommitment,
+			expectedErr:    "VSS commitment of threshold [3] is greater than the group size [2]",
+		},
+		"truncated VSS commitment": {
+			signerIndex:    keyShare.SignerIndex,
+			groupSize:      5,
+			secretKeyShare: keyShare.SecretKeyShare,
+			vssCommitment:  output.VSSCommitment[:1],
+			expectedErr:    "VSS commitment must have at least [2] elements; has [1]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, _, err := NewVerifiedSigner(
+				ciphersuite,
+				test.signerIndex,
+				test.groupSize,
+				test.secretKeyShare,
+				test.vssCommitment,
+			)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			testutils.AssertStringsEqual(
+				t,
+				"new verified signer error",
+				test.expectedErr,
+				err.Error(),
+			)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package frost
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// keyTweak accumulates tweaks applied to the group public key P. The tweaked
+// public key is always of the form Q = g*P + t*G, where g is 1 or -1, the same
+// way [BIP-327] tracks tweaks of the aggregated MuSig2 key. The signature
+// produced with the tweak is valid under the tweaked public key Q instead of
+// the group public key P.
+//
+// [BIP-327]: https://github.com/bitcoin/bips/blob/master/bip-0327.mediawiki
+type keyTweak struct {
+	publicKey *Point   // Q
+	negated   bool     // true if g = -1
+	scalar    *big.Int // t
+}
+
+// ApplyTweak tweaks the public key the participant produces signatures for
+// with the scalar t, that is, Q' = Q + t*G, where Q is the group public key
+// or the public key tweaked so far. This is how [BIP-32] derives unhardened
+// child public keys. All participants of the signing must apply the same
+// tweaks in the same order.
+//
+// The tweak must be a scalar lower than the group order and the tweaked
+// public key must not be the identity element of the group. The function
+// returns an error otherwise and the participant keeps its current tweak, if
+// any.
+//
+// [BIP-32]: https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki
+func (p *Participant) ApplyTweak(tweak *big.Int) error {
+	return p.applyTweak(tweak, false)
+}
+
+// ApplyXOnlyTweak is like ApplyTweak but if the ciphersuite requires the
+// even Y coordinate of the public key, the tweak is applied to the public key
+// with an even Y, as [BIP-341] does for x-only internal keys:
+//
+//	Q' = Q + t*G if has_even_y(Q), otherwise Q' = -Q + t*G
+//
+// [BIP-341]: https://github.com/bitcoin/bips/blob/master/bip-0341.mediawiki
+func (p *Participant) ApplyXOnlyTweak(tweak *big.Int) error {
+	return p.applyTweak(tweak, true)
+}
+
+func (p *Participant) applyTweak(tweak *big.Int, xOnly bool) error {
+	curve := p.ciphersuite.Curve()
+	order := curve.Order()
+
+	if tweak == nil || tweak.Sign() < 0 || tweak.Cmp(order) >= 0 {
+		return fmt.Errorf("tweak is not a valid scalar")
+	}
+
+	current := p.tweak
+	if current == nil {
+		current = &keyTweak{
+			publicKey: p.publicKey,
+			negated:   false,
+			scalar:    big.NewInt(0),
+		}
+	}
+
+	publicKey := current.publicKey
+	negated := current.negated
+	scalar := new(big.Int).Set(current.scalar)
+
+	// -Q = -g*P - t*G
+	if xOnly && p.hasOddY(publicKey) {
+		publicKey = curve.EcSub(curve.Identity(), publicKey)
+		negated = !negated
+		scalar.Sub(order, scalar)
+	}
+
+	publicKey = curve.EcAdd(publicKey, curve.EcBaseMul(tweak))
+	if !curve.IsPointOnCurve(publicKey) {
+		return fmt.Errorf("tweaked public key is the identity element")
+	}
+
+	scalar.Add(scalar, tweak)
+	scalar.Mod(scalar, order)
+
+	p.tweak = &keyTweak{
+		publicKey: publicKey,
+		negated:   negated,
+		scalar:    scalar,
+	}
+
+	return nil
+}
+
+// TweakedPublicKey returns the public key the signatures produced by the
+// participant are valid under. This is the tweaked public key Q if any tweak
+// was applied or the group public key otherwise.
+func (p *Participant) TweakedPublicKey() *Point {
+	return p.signingKey()
+}
+
+// signingKey returns the public key used in the signature challenge: the
+// tweaked public key Q if any tweak was applied or the group public key
+// otherwise.
+func (p *Participant) signingKey() *Point {
+	if p.tweak != nil {
+		return p.tweak.publicKey
+	}
+	return p.publicKey
+}
+
+// negatesKeyShare returns true if the secret key shares and the verification
+// shares must be negated for the signature to be valid under the signing
+// key. This is the case if the group public key was negated by x-only tweaks,
+// that is, g = -1, or if the signing key has an odd Y coordinate and the
+// ciphersuite requires an even one. Both negations cancel each other out.
+func (p *Participant) negatesKeyShare() bool {
+	negates := p.hasOddY(p.signingKey())
+	if p.tweak != nil && p.tweak.negated {
+		negates = !negates
+	}
+	return negates
+}
+
+// tweakTerm returns the contribution of the tweak to the signature:
+// challenge * t, negated if the signing key has an odd Y coordinate and the
+// ciphersuite requires an even one. The function returns zero if no tweak
+// was applied.
+func (p *Participant) tweakTerm(challenge *big.Int) *big.Int {
+	if p.tweak == nil {
+		return big.NewInt(0)
+	}
+
+	order := p.ciphersuite.Curve().Order()
+
+	tweak := p.tweak.scalar
+	if p.hasOddY(p.tweak.publicKey) {
+		tweak = new(big.Int).Sub(order, tweak)
+	}
+
+	term := new(big.Int).Mul(challenge, tweak)
+	return term.Mod(term, order)
+}
@@ -0,0 +1,272 @@
+package frost
+
+import (
+	"math/big"
+	"testing"
+
+	"threshold.network/roast/internal/testutils"
+)
+
+func TestFrostRoundtrip_TaprootTweak(t *testing.T) {
+	message := []byte("For even the very wise cannot see all ends")
+
+	merkleRoot := ciphersuite.H4([]byte("script tree"))
+
+	tests := map[string]struct {
+		oddY       bool
+		merkleRoot []byte
+	}{
+		"key path only, even Y of the public key": {
+			oddY:       false,
+			merkleRoot: nil,
+		},
+		"key path only, odd Y of the public key": {
+			oddY:       true,
+			merkleRoot: nil,
+		},
+		"script tree, even Y of the public key": {
+			oddY:       false,
+			merkleRoot: merkleRoot,
+		},
+		"script tree, odd Y of the public key": {
+			oddY:       true,
+			merkleRoot: merkleRoot,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			secretKey := generateSecretKey(t, test.oddY)
+			signers := createGroupSigners(
+				t,
+				secretKey,
+				smallThreshold,
+				smallGroupSize,
+			)[:smallThreshold]
+			publicKey := signers[0].publicKey
+
+			tweak, err := ciphersuite.TaprootTweak(publicKey, test.merkleRoot)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, signer := range signers {
+				if err := signer.ApplyXOnlyTweak(tweak); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			coordinator := NewCoordinator(
+				ciphersuite,
+				publicKey,
+				smallThreshold,
+				smallGroupSize,
+				verificationShares(signers),
+			)
+			if err := coordinator.ApplyXOnlyTweak(tweak); err != nil {
+				t.Fatal(err)
+			}
+
+			tweakedPublicKey := coordinator.TweakedPublicKey()
+
+			// The group commitment has an odd Y coordinate in about half of
+			// the signings. Signing a few times covers both cases.
+			for i := 0; i < 4; i++ {
+				commitments := executeRound1(t, signers)
+				signatureShares := executeRound2(t, signers, message, commitments)
+
+				// Signature shares are verified if the aggregated signature
+				// is invalid so the identifiable aggregation covers both.
+				signature, err := coordinator.AggregateIdentifiable(
+					message,
+					commitments,
+					signatureShares,
+				)
+				if err != nil {
+					t.Fatalf("signing [%d]: [%v]", i, err)
+				}
+
+				valid, err := ciphersuite.VerifySignature(
+					signature,
+					tweakedPublicKey,
+					message,
+				)
+				if err != nil {
+					t.Fatalf("signing [%d]: [%v]", i, err)
+				}
+				testutils.AssertBoolsEqual(t, "tweaked key signature validity", true, valid)
+
+				valid, _ = ciphersuite.VerifySignature(signature, publicKey, message)
+				testutils.AssertBoolsEqual(t, "internal key signature validity", false, valid)
+
+				for j, signer := range signers {
+					ok, err := coordinator.VerifySignatureShare(
+						signer.signerIndex,
+						signatureShares[j],
+						commitments,
+						message,
+					)
+					if err != nil {
+						t.Fatal(err)
+					}
+					testutils.AssertBoolsEqual(t, "signature share validity", true, ok)
+				}
+			}
+		})
+	}
+}
+
+func TestFrostRoundtrip_DerivedKey(t *testing.T) {
+	message := []byte("For even the very wise cannot see all ends")
+
+	curve := ciphersuite.Curve()
+	chainCode := ciphersuite.H4([]byte("chain code"))
+
+	tests := map[string]struct {
+		oddY    bool
+		taproot bool
+	}{
+		"even Y of the public key": {
+			oddY:    false,
+			taproot: false,
+		},
+		"odd Y of the public key": {
+			oddY:    true,
+			taproot: false,
+		},
+		"derived key as the Taproot internal key": {
+			oddY:    true,
+			taproot: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			secretKey := generateSecretKey(t, test.oddY)
+			signers := createGroupSigners(
+				t,
+				secretKey,
+				smallThreshold,
+				smallGroupSize,
+			)[:smallThreshold]
+			publicKey := signers[0].publicKey
+
+			derived, err := DeriveChildKey(curve, publicKey, chainCode, []uint32{0, 3})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			coordinator := NewCoordinator(
+				ciphersuite,
+				publicKey,
+				smallThreshold,
+				smallGroupSize,
+				verificationShares(signers),
+			)
+
+			participants := []*Participant{&coordinator.Participant}
+			for _, signer := range signers {
+				participants = append(participants, &signer.Participant)
+			}
+
+			var taprootTweak *big.Int
+			if test.taproot {
+				taprootTweak, err = ciphersuite.TaprootTweak(derived.PublicKey, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for _, participant := range participants {
+				if err := participant.ApplyTweak(derived.Tweak); err != nil {
+					t.Fatal(err)
+				}
+				if taprootTweak == nil {
+					continue
+				}
+				if err := participant.ApplyXOnlyTweak(taprootTweak); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			expectedKey := derived.PublicKey
+			if test.taproot {
+				expectedKey, err = ciphersuite.liftX(derived.PublicKey.X)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expectedKey = curve.EcAdd(expectedKey, curve.EcBaseMul(taprootTweak))
+			}
+			testutils.AssertBytesEqual(
+				t,
+				curve.SerializePoint(expectedKey),
+				curve.SerializePoint(coordinator.TweakedPublicKey()),
+			)
+
+			// The group commitment has an odd Y coordinate in about half of
+			// the signings. Signing a few times covers both cases.
+			for i := 0; i < 4; i++ {
+				commitments := executeRound1(t, signers)
+				signatureShares := executeRound2(t, signers, message, commitments)
+
+				signature, err := coordinator.AggregateIdentifiable(
+					message,
+					commitments,
+					signatureShares,
+				)
+				if err != nil {
+					t.Fatalf("signing [%d]: [%v]", i, err)
+				}
+
+				valid, err := ciphersuite.VerifySignature(
+					signature,
+					expectedKey,
+					message,
+				)
+				if err != nil {
+					t.Fatalf("signing [%d]: [%v]", i, err)
+				}
+				testutils.AssertBoolsEqual(t, "derived key signature validity", true, valid)
+			}
+		})
+	}
+}
+
+func TestApplyTweak_Failures(t *testing.T) {
+	signer := createSigners(t)[0]
+	order := ciphersuite.Curve().Order()
+
+	tests := map[string]struct {
+		tweak       *big.Int
+		expectedErr string
+	}{
+		"nil tweak": {
+			tweak:       nil,
+			expectedErr: "tweak is not a valid scalar",
+		},
+		"negative tweak": {
+			tweak:       big.NewInt(-1),
+			expectedErr: "tweak is not a valid scalar",
+		},
+		"tweak equal to the group order": {
+			tweak:       order,
+			expectedErr: "tweak is not a valid scalar",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := signer.ApplyTweak(test.tweak)
+			testutils.AssertStringsEqual(
+				t,
+				"apply tweak error",
+				test.expectedErr,
+				err.Error(),
+			)
+
+			if signer.TweakedPublicKey() != signer.publicKey {
+				t.Error("expected the public key not to be tweaked")
+			}
+		})
+	}
+}
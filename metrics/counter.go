@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Counter is a monotonically increasing metric, optionally partitioned by
+// labels. Counter is safe for concurrent use.
+type Counter struct {
+	name       string
+	help       string
+	labelNames []string
+
+	mutex  sync.Mutex
+	values map[string]*counterValue
+}
+
+type counterValue struct {
+	labelValues []string
+	value       float64
+}
+
+// NewCounter creates a new Counter with the given name, help text, and label
+// names.
+func NewCounter(name string, help string, labelNames ...string) *Counter {
+	return &Counter{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		values:     make(map[string]*counterValue),
+	}
+}
+
+// Name returns the name of the counter.
+func (c *Counter) Name() string {
+	return c.name
+}
+
+// Inc increments the counter with the given label values by one.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds the given non-negative delta to the counter with the given label
+// values. The function panics if the delta is negative or the number of label
+// values does not match the number of label names as both are programming
+// errors.
+func (c *Counter) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		panic(fmt.Sprintf("counter [%s] cannot decrease", c.name))
+	}
+	c.checkLabels(labelValues)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := labelKey(labelValues)
+	value, ok := c.values[key]
+	if !ok {
+		value = &counterValue{labelValues: append([]string{}, labelValues...)}
+		c.values[key] = value
+	}
+	value.value += delta
+}
+
+// Value returns the current value of the counter with the given label values.
+func (c *Counter) Value(labelValues ...string) float64 {
+	c.checkLabels(labelValues)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if value, ok := c.values[labelKey(labelValues)]; ok {
+		return value.value
+	}
+	return 0
+}
+
+// WriteText writes the counter in the [Prometheus] text exposition format.
+// Samples are sorted by label values.
+func (c *Counter) WriteText(w io.Writer) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := writeHeader(w, c.name, c.help, "counter"); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(c.values))
+	for key := range c.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := c.values[key]
+		err := writeSample(w, c.name, c.labelNames, value.labelValues, value.value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Counter) checkLabels(labelValues []string) {
+	if len(labelValues) != len(c.labelNames) {
+		panic(fmt.Sprintf(
+			"counter [%s] has [%d] labels but [%d] label values were given",
+			c.name,
+			len(c.labelNames),
+			len(labelValues),
+		))
+	}
+}
+
+// labelKey joins label values with a byte that is not valid UTF-8 so that
+// different label values never produce the same key.
+func labelKey(labelValues []string) string {
+	return strings.Join(labelValues, "\xff")
+}
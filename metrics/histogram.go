@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+)
+
+// DefaultBuckets are the default upper bounds of histogram buckets, suitable
+// for latencies measured in seconds.
+var DefaultBuckets = []float64{
+	.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60,
+}
+
+// Histogram samples observations and counts them in buckets. Histogram is
+// safe for concurrent use.
+type Histogram struct {
+	name    string
+	help    string
+	buckets []float64 // upper bounds, sorted, excluding +Inf
+
+	mutex  sync.Mutex
+	counts []uint64 // non-cumulative, the last one is for +Inf
+	sum    float64
+	count  uint64
+}
+
+// NewHistogram creates a new Histogram with the given name, help text, and
+// bucket upper bounds. If no buckets are given, DefaultBuckets are used. The
+// +Inf bucket is always added.
+func NewHistogram(name string, help string, buckets []float64) *Histogram {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+
+	sorted := make([]float64, 0, len(buckets))
+	for _, bucket := range buckets {
+		if !math.IsInf(bucket, 1) {
+			sorted = append(sorted, bucket)
+		}
+	}
+	sort.Float64s(sorted)
+
+	return &Histogram{
+		name:    name,
+		help:    help,
+		buckets: sorted,
+		counts:  make([]uint64, len(sorted)+1),
+	}
+}
+
+// Name returns the name of the histogram.
+func (h *Histogram) Name() string {
+	return h.name
+}
+
+// Observe adds a single observation to the histogram.
+func (h *Histogram) Observe(value float64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	i := sort.SearchFloat64s(h.buckets, value)
+	h.counts[i]++
+	h.sum += value
+	h.count++
+}
+
+// Count returns the number of observations.
+func (h *Histogram) Count() uint64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.count
+}
+
+// Sum returns the sum of all observations.
+func (h *Histogram) Sum() float64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.sum
+}
+
+// WriteText writes the histogram in the [Prometheus] text exposition format.
+func (h *Histogram) WriteText(w io.Writer) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if err := writeHeader(w, h.name, h.help, "histogram"); err != nil {
+		return err
+	}
+
+	bucketName := fmt.Sprintf("%s_bucket", h.name)
+	labelNames := []string{"le"}
+
+	var cumulative uint64
+	for i, bucket := range h.buckets {
+		cumulative += h.counts[i]
+		err := writeSample(
+			w,
+			bucketName,
+			labelNames,
+			[]string{formatFloat(bucket)},
+			float64(cumulative),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := writeSample(
+		w,
+		bucketName,
+		labelNames,
+		[]string{formatFloat(math.Inf(1))},
+		float64(h.count),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := writeSample(w, h.name+"_sum", nil, nil, h.sum); err != nil {
+		return err
+	}
+
+	return writeSample(w, h.name+"_count", nil, nil, float64(h.count))
+}
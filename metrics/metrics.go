@@ -0,0 +1,182 @@
+// Package metrics implements counters and histograms that can be rendered in
+// the [Prometheus] text exposition format, using only the standard library,
+// and an observer collecting metrics of [ROAST] and [FROST] signing.
+//
+// [Prometheus]
+//
+//	"Exposition formats", Prometheus documentation,
+//	<https://prometheus.io/docs/instrumenting/exposition_formats/>.
+//
+// [ROAST]
+//
+//	Ruffing T., Ronge V., Jin E., Schneider-Bensch J., Schroder D.,
+//	"ROAST: Robust Asynchronous Schnorr Threshold Signatures"
+//	<https://eprint.iacr.org/2022/550.pdf>
+//
+// [FROST]
+//
+//	Connolly, D., Komlo, C., Goldberg, I., and C. A. Wood, "Two-Round
+//	Threshold Schnorr Signatures with FROST", Work in Progress, Internet-Draft,
+//	draft-irtf-cfrg-frost-15, 5 December 2023,
+//	<https://datatracker.ietf.org/doc/draft-irtf-cfrg-frost/15/>.
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Collector is a single metric that can be rendered in the [Prometheus] text
+// exposition format.
+type Collector interface {
+	// Name returns the name of the metric.
+	Name() string
+
+	// WriteText writes the metric, including the HELP and TYPE lines, in the
+	// [Prometheus] text exposition format.
+	WriteText(w io.Writer) error
+}
+
+// Registry is a set of metrics rendered together. Registry implements
+// http.Handler so it can be exposed for scraping directly.
+type Registry struct {
+	mutex      sync.Mutex
+	collectors []Collector
+}
+
+// NewRegistry creates a new, empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds the metric to the registry. The function returns an error if
+// a metric with the same name is already registered.
+func (r *Registry) Register(collector Collector) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, c := range r.collectors {
+		if c.Name() == collector.Name() {
+			return fmt.Errorf(
+				"metric [%s] is already registered",
+				collector.Name(),
+			)
+		}
+	}
+
+	r.collectors = append(r.collectors, collector)
+	return nil
+}
+
+// WriteText writes all registered metrics, sorted by name, in the
+// [Prometheus] text exposition format.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mutex.Lock()
+	collectors := make([]Collector, len(r.collectors))
+	copy(collectors, r.collectors)
+	r.mutex.Unlock()
+
+	sort.Slice(collectors, func(i, j int) bool {
+		return collectors[i].Name() < collectors[j].Name()
+	})
+
+	for _, c := range collectors {
+		if err := c.WriteText(w); err != nil {
+			return fmt.Errorf("could not write metric [%s]: [%v]", c.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// ServeHTTP renders all registered metrics in the [Prometheus] text
+// exposition format.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	var buffer bytes.Buffer
+	if err := r.WriteText(&buffer); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = buffer.WriteTo(w)
+}
+
+// writeHeader writes the HELP and TYPE lines of the metric.
+func writeHeader(w io.Writer, name, help, metricType string) error {
+	_, err := fmt.Fprintf(
+		w,
+		"# HELP %s %s\n# TYPE %s %s\n",
+		name,
+		escapeHelp(help),
+		name,
+		metricType,
+	)
+	return err
+}
+
+// writeSample writes a single sample line of the metric.
+func writeSample(
+	w io.Writer,
+	name string,
+	labelNames []string,
+	labelValues []string,
+	value float64,
+) error {
+	var line strings.Builder
+	line.WriteString(name)
+
+	if len(labelNames) > 0 {
+		line.WriteByte('{')
+		for i, labelName := range labelNames {
+			if i > 0 {
+				line.WriteByte(',')
+			}
+			line.WriteString(labelName)
+			line.WriteString(`="`)
+			line.WriteString(escapeLabelValue(labelValues[i]))
+			line.WriteByte('"')
+		}
+		line.WriteByte('}')
+	}
+
+	line.WriteByte(' ')
+	line.WriteString(formatFloat(value))
+	line.WriteByte('\n')
+
+	_, err := io.WriteString(w, line.String())
+	return err
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+var (
+	helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
@@ -0,0 +1,134 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"threshold.network/roast/internal/testutils"
+)
+
+func TestCounter(t *testing.T) {
+	counter := NewCounter("requests_total", "Number of requests.", "method", "path")
+
+	counter.Inc("GET", "/")
+	counter.Inc("GET", "/")
+	counter.Add(2.5, "POST", `/a"b\c`)
+
+	if counter.Value("GET", "/") != 2 {
+		t.Errorf("unexpected counter value: [%v]", counter.Value("GET", "/"))
+	}
+	if counter.Value("PUT", "/") != 0 {
+		t.Errorf("unexpected counter value: [%v]", counter.Value("PUT", "/"))
+	}
+
+	var buffer bytes.Buffer
+	if err := counter.WriteText(&buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "# HELP requests_total Number of requests.\n" +
+		"# TYPE requests_total counter\n" +
+		"requests_total{method=\"GET\",path=\"/\"} 2\n" +
+		"requests_total{method=\"POST\",path=\"/a\\\"b\\\\c\"} 2.5\n"
+	testutils.AssertStringsEqual(t, "counter text", expected, buffer.String())
+}
+
+func TestCounter_Panics(t *testing.T) {
+	tests := map[string]struct {
+		fn func(counter *Counter)
+	}{
+		"negative delta": {
+			fn: func(counter *Counter) { counter.Add(-1, "a") },
+		},
+		"missing label value": {
+			fn: func(counter *Counter) { counter.Inc() },
+		},
+		"too many label values": {
+			fn: func(counter *Counter) { counter.Inc("a", "b") },
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			test.fn(NewCounter("counter", "Counter.", "label"))
+		})
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	histogram := NewHistogram("latency_seconds", "Latency.", []float64{1, 0.5})
+
+	for _, value := range []float64{0.1, 0.5, 0.7, 3} {
+		histogram.Observe(value)
+	}
+
+	testutils.AssertUintsEqual(t, "histogram count", 4, histogram.Count())
+	if histogram.Sum() != 4.3 {
+		t.Errorf("unexpected histogram sum: [%v]", histogram.Sum())
+	}
+
+	var buffer bytes.Buffer
+	if err := histogram.WriteText(&buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "# HELP latency_seconds Latency.\n" +
+		"# TYPE latency_seconds histogram\n" +
+		"latency_seconds_bucket{le=\"0.5\"} 2\n" +
+		"latency_seconds_bucket{le=\"1\"} 3\n" +
+		"latency_seconds_bucket{le=\"+Inf\"} 4\n" +
+		"latency_seconds_sum 4.3\n" +
+		"latency_seconds_count 4\n"
+	testutils.AssertStringsEqual(t, "histogram text", expected, buffer.String())
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+
+	counter := NewCounter("b_total", "B.")
+	counter.Inc()
+	if err := registry.Register(counter); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Register(NewHistogram("a_seconds", "A.", []float64{1})); err != nil {
+		t.Fatal(err)
+	}
+
+	err := registry.Register(NewCounter("b_total", "Another B."))
+	if err == nil {
+		t.Fatal("expected non-nil error")
+	}
+	testutils.AssertStringsEqual(
+		t,
+		"register error",
+		"metric [b_total] is already registered",
+		err.Error(),
+	)
+
+	recorder := httptest.NewRecorder()
+	registry.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	testutils.AssertStringsEqual(
+		t,
+		"content type",
+		"text/plain; version=0.0.4; charset=utf-8",
+		recorder.Header().Get("Content-Type"),
+	)
+
+	expected := "# HELP a_seconds A.\n" +
+		"# TYPE a_seconds histogram\n" +
+		"a_seconds_bucket{le=\"1\"} 0\n" +
+		"a_seconds_bucket{le=\"+Inf\"} 0\n" +
+		"a_seconds_sum 0\n" +
+		"a_seconds_count 0\n" +
+		"# HELP b_total B.\n" +
+		"# TYPE b_total counter\n" +
+		"b_total 1\n"
+	testutils.AssertStringsEqual(t, "registry text", expected, recorder.Body.String())
+}
@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"threshold.network/roast/frost"
+	"threshold.network/roast/roast"
+)
+
+// Observer is a frost.Observer collecting metrics of [ROAST] and [FROST]
+// signing:
+//   - roast_events_total, the number of events reported, by event kind,
+//   - roast_share_latency_seconds, the time between the start of the
+//     [ROAST] session and the signature share of the signer being received
+//     or rejected,
+//   - roast_signature_latency_seconds, the time between the start of the
+//     first [ROAST] session and the signature being produced.
+//
+// Latencies are measured only for events reported by the [ROAST]
+// coordinator. A single Observer should be set on a single [ROAST]
+// coordinator as session identifiers are not unique across coordinators.
+//
+// The start of a session is kept until all signers of the session responded,
+// one of them was marked as malicious in the session, or the signature was
+// produced. Shares received after that are counted but their latency is not
+// measured.
+type Observer struct {
+	Events           *Counter
+	ShareLatency     *Histogram
+	SignatureLatency *Histogram
+
+	mutex         sync.Mutex
+	sessionStarts map[uint64]*sessionStart
+	firstStart    time.Time
+}
+
+// sessionStart is the start time of a [ROAST] session along with the signers
+// of the session that have not responded yet.
+type sessionStart struct {
+	time    time.Time
+	pending map[uint64]bool
+}
+
+// NewObserver creates a new Observer and registers its metrics in the
+// registry.
+func NewObserver(registry *Registry) (*Observer, error) {
+	o := &Observer{
+		Events: NewCounter(
+			"roast_events_total",
+			"Number of signing events reported, by event kind.",
+			"kind",
+		),
+		ShareLatency: NewHistogram(
+			"roast_share_latency_seconds",
+			"Time between the session start and the signature share of "+
+				"the signer being received or rejected.",
+			DefaultBuckets,
+		),
+		SignatureLatency: NewHistogram(
+			"roast_signature_latency_seconds",
+			"Time between the first session start and the signature "+
+				"being produced.",
+			DefaultBuckets,
+		),
+		sessionStarts: make(map[uint64]*sessionStart),
+	}
+
+	for _, c := range []Collector{o.Events, o.ShareLatency, o.SignatureLatency} {
+		if err := registry.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return o, nil
+}
+
+// Observe records the event.
+func (o *Observer) Observe(event *frost.Event) {
+	o.Events.Inc(string(event.Kind))
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	switch event.Kind {
+	case roast.EventSessionStarted:
+		start := &sessionStart{
+			time:    event.Time,
+			pending: make(map[uint64]bool, len(event.Signers)),
+		}
+		for _, signerIndex := range event.Signers {
+			start.pending[signerIndex] = true
+		}
+		o.sessionStarts[event.SessionID] = start
+		if o.firstStart.IsZero() {
+			o.firstStart = event.Time
+		}
+	case frost.EventShareReceived, frost.EventShareRejected:
+		start, ok := o.sessionStarts[event.SessionID]
+		if !ok || !start.pending[event.SignerIndex] {
+			break
+		}
+		o.ShareLatency.Observe(event.Time.Sub(start.time).Seconds())
+		delete(start.pending, event.SignerIndex)
+		if len(start.pending) == 0 {
+			delete(o.sessionStarts, event.SessionID)
+		}
+	case roast.EventSignerMarkedMalicious:
+		// The session the signer misbehaved in can not produce the
+		// signature anymore.
+		delete(o.sessionStarts, event.SessionID)
+	case frost.EventSignatureProduced:
+		if !o.firstStart.IsZero() && event.SessionID != 0 {
+			o.SignatureLatency.Observe(event.Time.Sub(o.firstStart).Seconds())
+		}
+		// No more shares are expected once the signature is produced.
+		o.sessionStarts = make(map[uint64]*sessionStart)
+		o.firstStart = time.Time{}
+	}
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"threshold.network/roast/frost"
+	"threshold.network/roast/internal/testutils"
+	"threshold.network/roast/roast"
+)
+
+func TestObserver(t *testing.T) {
+	registry := NewRegistry()
+
+	observer, err := NewObserver(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+
+	events := []*frost.Event{
+		{Kind: roast.EventSessionStarted, SessionID: 1, Signers: []uint64{1, 2, 3}, Time: at(0)},
+		{Kind: frost.EventShareReceived, SessionID: 1, SignerIndex: 1, Time: at(100 * time.Millisecond)},
+		{Kind: frost.EventShareRejected, SessionID: 1, SignerIndex: 2, Time: at(2 * time.Second)},
+		{Kind: roast.EventSignerMarkedMalicious, SessionID: 1, SignerIndex: 2, Time: at(2 * time.Second)},
+		{Kind: roast.EventSessionStarted, SessionID: 2, Signers: []uint64{1, 3}, Time: at(3 * time.Second)},
+		{Kind: frost.EventShareReceived, SessionID: 2, SignerIndex: 1, Time: at(4 * time.Second)},
+		{Kind: frost.EventSignatureProduced, SessionID: 2, Time: at(4 * time.Second)},
+		// shares of other sessions received after the signature was
+		// produced are counted but their latency is not measured
+		{Kind: frost.EventShareReceived, SessionID: 1, SignerIndex: 3, Time: at(5 * time.Second)},
+	}
+	for _, event := range events {
+		observer.Observe(event)
+	}
+
+	if v := observer.Events.Value(string(frost.EventShareReceived)); v != 3 {
+		t.Errorf("unexpected number of share received events: [%v]", v)
+	}
+	if v := observer.Events.Value(string(roast.EventSessionStarted)); v != 2 {
+		t.Errorf("unexpected number of session started events: [%v]", v)
+	}
+
+	testutils.AssertUintsEqual(t, "share latency count", 3, observer.ShareLatency.Count())
+	if sum := observer.ShareLatency.Sum(); sum != 3.1 {
+		t.Errorf("unexpected share latency sum: [%v]", sum)
+	}
+
+	testutils.AssertUintsEqual(t, "signature latency count", 1, observer.SignatureLatency.Count())
+	if sum := observer.SignatureLatency.Sum(); sum != 4 {
+		t.Errorf("unexpected signature latency sum: [%v]", sum)
+	}
+
+	if _, err := NewObserver(registry); err == nil {
+		t.Fatal("expected non-nil error")
+	}
+}
+
+func TestObserver_SessionStartsReleased(t *testing.T) {
+	observer, err := NewObserver(NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+
+	events := []*frost.Event{
+		// all signers of the session responded
+		{Kind: roast.EventSessionStarted, SessionID: 1, Signers: []uint64{1, 2}, Time: at(0)},
+		{Kind: frost.EventShareReceived, SessionID: 1, SignerIndex: 1, Time: at(time.Second)},
+		{Kind: frost.EventShareReceived, SessionID: 1, SignerIndex: 2, Time: at(time.Second)},
+		// the session was abandoned as one of the signers misbehaved
+		{Kind: roast.EventSessionStarted, SessionID: 2, Signers: []uint64{1, 3}, Time: at(0)},
+		{Kind: roast.EventSignerMarkedMalicious, SessionID: 2, SignerIndex: 3, Time: at(time.Second)},
+		{Kind: frost.EventShareReceived, SessionID: 2, SignerIndex: 1, Time: at(time.Second)},
+	}
+	for _, event := range events {
+		observer.Observe(event)
+	}
+
+	testutils.AssertUintsEqual(t, "share latency count", 2, observer.ShareLatency.Count())
+	testutils.AssertIntsEqual(t, "number of session starts", 0, len(observer.sessionStarts))
+}
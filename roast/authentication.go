@@ -0,0 +1,137 @@
+package roast
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"threshold.network/roast/frost"
+)
+
+// authenticatedData returns the data the signer authenticates for its
+// response for a single message. Along with the signature share and the next
+// nonce commitment, the data covers the session the signer responded to, so
+// that the signature share is bound to the message and commitments it was
+// produced for. The initial response is not bound to any session: the session
+// identifier is zero and the message and commitments are empty.
+//
+// Commitments and the signature share are encoded the same way blame records
+// keep them so that the authentication can be verified from the blame record
+// alone. Every variable-length field is prefixed with its length so that the
+// encoding is unambiguous.
+func authenticatedData(
+	signerIndex uint64,
+	sessionID uint64,
+	message []byte,
+	commitments []*BlameCommitment,
+	signatureShare []byte,
+	nextCommitment *BlameCommitment,
+) []byte {
+	b := binary.BigEndian.AppendUint64(nil, signerIndex)
+	b = binary.BigEndian.AppendUint64(b, sessionID)
+	b = appendField(b, message)
+	b = binary.BigEndian.AppendUint64(b, uint64(len(commitments)))
+	for _, commitment := range commitments {
+		b = appendCommitment(b, commitment)
+	}
+	b = appendField(b, signatureShare)
+	return appendCommitment(b, nextCommitment)
+}
+
+// responseData returns the data the signer authenticates for the j-th
+// message of the response to the session. The session is nil for the
+// initial response. All signature shares that are set must be valid scalars.
+func responseData(
+	curve frost.Curve,
+	response *BatchResponse,
+	j int,
+	session *BatchSession,
+) []byte {
+	var message []byte
+	var commitments []*BlameCommitment
+	if session != nil && j < len(session.Messages) && j < len(session.Commitments) {
+		message = session.Messages[j]
+		for _, commitment := range session.Commitments[j] {
+			commitments = append(commitments, newBlameCommitment(curve, commitment))
+		}
+	}
+
+	var signatureShare []byte
+	if j < len(response.SignatureShares) && response.SignatureShares[j] != nil {
+		signatureShare = frost.SerializeScalar(curve, response.SignatureShares[j])
+	}
+
+	var nextCommitment *BlameCommitment
+	if j < len(response.NextCommitments) {
+		nextCommitment = newBlameCommitment(curve, response.NextCommitments[j])
+	}
+
+	return authenticatedData(
+		response.SignerIndex,
+		response.SessionID,
+		message,
+		commitments,
+		signatureShare,
+		nextCommitment,
+	)
+}
+
+// appendField appends the byte slice prefixed with its length.
+func appendField(b []byte, field []byte) []byte {
+	b = binary.BigEndian.AppendUint64(b, uint64(len(field)))
+	return append(b, field...)
+}
+
+// appendCommitment appends the commitment prefixed with a byte telling if
+// the commitment is set.
+func appendCommitment(b []byte, commitment *BlameCommitment) []byte {
+	if commitment == nil {
+		return append(b, 0)
+	}
+	b = append(b, 1)
+	b = binary.BigEndian.AppendUint64(b, commitment.SignerIndex)
+	b = appendField(b, commitment.HidingNonceCommitment)
+	return appendField(b, commitment.BindingNonceCommitment)
+}
+
+// encodeAuthentication encodes the authentication as the point R serialized
+// with the curve's SerializePoint followed by the scalar z serialized with
+// frost.SerializeScalar. The function returns nil for nil authentication.
+// The authentication must be well-formed.
+func encodeAuthentication(curve frost.Curve, authentication *frost.Signature) []byte {
+	if authentication == nil {
+		return nil
+	}
+	return append(
+		curve.SerializePoint(authentication.R),
+		frost.SerializeScalar(curve, authentication.Z)...,
+	)
+}
+
+// decodeAuthentication decodes the authentication encoded with
+// encodeAuthentication. The function returns nil for an empty slice and an
+// error if the encoding is malformed.
+func decodeAuthentication(curve frost.Curve, b []byte) (*frost.Signature, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	pointLength := curve.SerializedPointLength()
+	if len(b) != pointLength+frost.SerializedScalarLength(curve) {
+		return nil, fmt.Errorf(
+			"authentication must be [%d] bytes long; has [%d]",
+			pointLength+frost.SerializedScalarLength(curve),
+			len(b),
+		)
+	}
+
+	R := curve.DeserializePoint(b[:pointLength])
+	if R == nil {
+		return nil, fmt.Errorf("authentication point is not a valid point on the curve")
+	}
+	z, err := frost.DeserializeScalar(curve, b[pointLength:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &frost.Signature{R: R, Z: z}, nil
+}
@@ -0,0 +1,150 @@
+package roast
+
+import (
+	"context"
+	"fmt"
+
+	"threshold.network/roast/frost"
+)
+
+// BatchCoordinator represents the coordinator of the batch mode of [ROAST].
+// The batch coordinator works exactly like the Coordinator but every session
+// covers several messages at once: signers respond with one signature share
+// and one fresh nonce commitment per message. This way, signing all inputs of
+// a Taproot transaction takes as many message exchanges as signing a single
+// input.
+//
+// A signer that provided an invalid signature share or nonce commitment for
+// any of the messages is marked as malicious and never included in any new
+// session, for any of the messages.
+//
+// Signatures are collected per message: once a session produced a valid
+// signature for the message, the signature is kept even if the signatures for
+// other messages of the session were not valid. The signing ends once there
+// is a valid signature for every message.
+type BatchCoordinator struct {
+	machine
+}
+
+// NewBatchCoordinator creates a new BatchCoordinator instance for signing
+// the given messages. Verification shares of all group members, indexed by
+// the signer identifier, are used to verify signature shares and identify
+// malicious signers. The function returns an error if there are no messages
+// to sign.
+func NewBatchCoordinator(
+	ciphersuite frost.Ciphersuite,
+	publicKey *frost.Point,
+	threshold int,
+	groupSize int,
+	verificationShares map[uint64]*frost.Point,
+	messages [][]byte,
+) (*BatchCoordinator, error) {
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("no messages to sign")
+	}
+
+	return &BatchCoordinator{
+		machine: newMachine(
+			ciphersuite,
+			publicKey,
+			threshold,
+			groupSize,
+			verificationShares,
+			messages,
+		),
+	}, nil
+}
+
+// NewBatchCoordinatorWithJournal creates a new BatchCoordinator instance for
+// signing the given messages that appends every signer response that may
+// change its state to the journal. If the journal is not empty, the
+// coordinator's state is restored from the journal before the function
+// returns, the same way NewCoordinatorWithJournal does.
+func NewBatchCoordinatorWithJournal(
+	ciphersuite frost.Ciphersuite,
+	publicKey *frost.Point,
+	threshold int,
+	groupSize int,
+	verificationShares map[uint64]*frost.Point,
+	messages [][]byte,
+	journal Journal,
+) (*BatchCoordinator, error) {
+	bc, err := NewBatchCoordinator(
+		ciphersuite,
+		publicKey,
+		threshold,
+		groupSize,
+		verificationShares,
+		messages,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bc.restore(journal); err != nil {
+		return nil, err
+	}
+
+	return bc, nil
+}
+
+// Receive processes the response from the signer. If the response makes the
+// number of responsive signers reach the threshold, a new session is started
+// and returned. The session should be delivered to all signers included in
+// it. Otherwise, the function returns nil session.
+//
+// The function returns an error if the response was rejected. If the signer
+// misbehaved, the signer is marked as malicious and the error explains why.
+func (bc *BatchCoordinator) Receive(response *BatchResponse) (*BatchSession, error) {
+	return bc.receive(response)
+}
+
+// Signatures returns the signatures produced by the coordinator, in the
+// order of messages, or nil if the signatures for all messages have not been
+// produced yet.
+func (bc *BatchCoordinator) Signatures() []*frost.Signature {
+	if !bc.signed() {
+		return nil
+	}
+	return bc.signatures
+}
+
+// Run executes the batch coordinator over the transport until the
+// signatures for all messages are produced, the same way Coordinator.Run
+// executes the coordinator. Once the signatures are produced, they are sent
+// to all signers and peers.
+//
+// The function returns an error if there are too many malicious signers to
+// produce the signatures or if the transport was closed before the signatures
+// were produced. If the context is done before the signatures were produced,
+// the function returns *InterruptedError with the partial result of the
+// signing.
+func (bc *BatchCoordinator) Run(
+	ctx context.Context,
+	transport Transport,
+	signers map[uint64]Address,
+	peers ...Address,
+) ([]*frost.Signature, error) {
+	return bc.run(ctx, transport, signers, peers, bc)
+}
+
+func (bc *BatchCoordinator) decodeResponse(message Message) (*BatchResponse, bool) {
+	response, ok := message.(*BatchResponse)
+	return response, ok && response != nil
+}
+
+func (bc *BatchCoordinator) encodeSession(session *BatchSession) Message {
+	return session
+}
+
+func (bc *BatchCoordinator) encodeResult(signatures []*frost.Signature) Message {
+	return &BatchResult{Messages: bc.messages, Signatures: signatures}
+}
+
+func (bc *BatchCoordinator) decodeResult(message Message) ([]*frost.Signature, bool) {
+	result, ok := message.(*BatchResult)
+	if !ok || result == nil {
+		return nil, false
+	}
+	return result.Signatures, true
+}
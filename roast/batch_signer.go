@@ -0,0 +1,271 @@
+package roast
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"threshold.network/roast/frost"
+)
+
+// BatchSigner represents a single signer of the batch mode of [ROAST]. The
+// batch signer wraps the Signer and executes [FROST] Round One and Round Two
+// once per message of the batch, attaching one fresh nonce commitment per
+// message to every response.
+type BatchSigner struct {
+	signer    *Signer
+	batchSize int
+}
+
+// NewBatchSigner creates a new BatchSigner instance signing batches of the
+// given size. Nonces are generated and spent by the wrapped signer so the
+// signer's journal, if any, is used for the batch as well.
+func NewBatchSigner(signer *Signer, batchSize int) *BatchSigner {
+	return &BatchSigner{
+		signer:    signer,
+		batchSize: batchSize,
+	}
+}
+
+// Commit generates a fresh nonce for every message of the batch and returns
+// the initial response carrying just the nonce commitments. The initial
+// response should be sent to the coordinator once, when the signing starts.
+func (bs *BatchSigner) Commit() (*BatchResponse, error) {
+	commitments, err := bs.nextCommitments()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BatchResponse{
+		SignerIndex:     bs.signer.signerIndex,
+		NextCommitments: commitments,
+	}
+	if err := bs.Authenticate(response, nil); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Sign produces the signature share for every message of the session. The
+// nonces for all messages are looked up and the commitment lists for all
+// messages are validated before any share is produced so that no nonce is
+// spent if the signer refuses to sign any of the messages. All commitment
+// lists must hold commitments of the same signers. Along with the shares, the
+// function returns fresh nonce commitments for the next session.
+func (bs *BatchSigner) Sign(session *BatchSession) (*BatchResponse, error) {
+	return bs.SignContext(context.Background(), session)
+}
+
+// SignContext is like Sign but stops with the context error if the context
+// is done before all signature shares are produced. Nonces for messages not
+// signed yet are not spent in such a case.
+func (bs *BatchSigner) SignContext(
+	ctx context.Context,
+	session *BatchSession,
+) (*BatchResponse, error) {
+	if session == nil {
+		return nil, fmt.Errorf("session is nil")
+	}
+
+	if len(session.Messages) != bs.batchSize ||
+		len(session.Commitments) != bs.batchSize {
+		return nil, fmt.Errorf(
+			"session [%d] has [%d] messages and [%d] commitment lists "+
+				"for batch size [%d]",
+			session.ID,
+			len(session.Messages),
+			len(session.Commitments),
+			bs.batchSize,
+		)
+	}
+
+	owns := make([]*frost.NonceCommitment, bs.batchSize)
+	for j := range session.Messages {
+		own, err := bs.signer.nonce(session.session(j))
+		if err != nil {
+			return nil, fmt.Errorf("message [%d]: [%v]", j, err)
+		}
+		// The same commitment must not be used for two messages.
+		for _, other := range owns[:j] {
+			if bs.signer.commitmentKey(other) == bs.signer.commitmentKey(own) {
+				return nil, fmt.Errorf(
+					"session [%d] uses the same commitment for two messages",
+					session.ID,
+				)
+			}
+		}
+		if err := bs.signer.validate(session.session(j), own); err != nil {
+			return nil, fmt.Errorf("message [%d]: [%v]", j, err)
+		}
+		owns[j] = own
+	}
+
+	// The commitment lists are valid so every commitment is set.
+	signers := session.Signers()
+	for j := range session.Messages {
+		if !slices.Equal(signers, session.session(j).Signers()) {
+			return nil, fmt.Errorf(
+				"session [%d] has different signers for message [%d]",
+				session.ID,
+				j,
+			)
+		}
+	}
+
+	shares := make([]*big.Int, bs.batchSize)
+	for j := range session.Messages {
+		share, err := bs.signer.signShare(ctx, session.session(j), owns[j])
+		if err != nil && err == ctx.Err() {
+			return nil, err
+		}
+		if err != nil {
+			return nil, fmt.Errorf("message [%d]: [%v]", j, err)
+		}
+		shares[j] = share
+	}
+
+	commitments, err := bs.nextCommitments()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BatchResponse{
+		SignerIndex:     bs.signer.signerIndex,
+		SessionID:       session.ID,
+		SignatureShares: shares,
+		NextCommitments: commitments,
+	}
+	if err := bs.Authenticate(response, session); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Authenticate sets the authentications of the response to the session, one
+// per message, the same way Signer.Authenticate does. The session must be
+// nil for the initial response.
+func (bs *BatchSigner) Authenticate(
+	response *BatchResponse,
+	session *BatchSession,
+) error {
+	authentications, err := bs.signer.authenticate(response, session)
+	if err != nil {
+		return err
+	}
+
+	response.Authentications = authentications
+	return nil
+}
+
+// nextCommitments executes [FROST] Round One for every message of the batch.
+func (bs *BatchSigner) nextCommitments() ([]*frost.NonceCommitment, error) {
+	commitments := make([]*frost.NonceCommitment, bs.batchSize)
+	for j := range commitments {
+		commitment, err := bs.signer.nextCommitment()
+		if err != nil {
+			return nil, err
+		}
+		commitments[j] = commitment
+	}
+	return commitments, nil
+}
+
+// Run executes the batch signer over the transport for the given
+// coordinator. The signer sends the initial commitments and then responds to
+// every session received from the coordinator. Sessions the signer refuses
+// to sign in are ignored.
+//
+// The function returns when the coordinator sends valid signatures for all
+// messages of a session this signer received or when the transport is closed.
+// If the context is done first, the function returns the context error.
+func (bs *BatchSigner) Run(
+	ctx context.Context,
+	transport Transport,
+	coordinator Address,
+) error {
+	response, err := bs.Commit()
+	if err != nil {
+		return err
+	}
+
+	if err := transport.Send(coordinator, response); err != nil {
+		return fmt.Errorf(
+			"could not send the initial commitments to [%s]: [%v]",
+			coordinator,
+			err,
+		)
+	}
+
+	messages := make(map[string]bool)
+
+	for {
+		var envelope *Envelope
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e, ok := <-transport.Receive():
+			if !ok {
+				return nil
+			}
+			envelope = e
+		}
+
+		if envelope.Sender != coordinator {
+			continue
+		}
+
+		switch message := envelope.Message.(type) {
+		case *BatchSession:
+			if message == nil {
+				continue
+			}
+			for _, m := range message.Messages {
+				messages[string(m)] = true
+			}
+
+			response, err := bs.SignContext(ctx, message)
+			if err != nil {
+				continue
+			}
+
+			// The delivery is not guaranteed anyway. If the response could
+			// not be sent, the coordinator sees this signer as not responsive.
+			_ = transport.Send(coordinator, response)
+		case *BatchResult:
+			if message != nil && bs.areSignaturesValid(message, messages) {
+				return nil
+			}
+		}
+	}
+}
+
+// areSignaturesValid returns true if the result holds a valid signature for
+// every message and all messages were seen in sessions this signer received.
+func (bs *BatchSigner) areSignaturesValid(
+	result *BatchResult,
+	messages map[string]bool,
+) bool {
+	if len(result.Messages) == 0 ||
+		len(result.Messages) != len(result.Signatures) {
+		return false
+	}
+
+	for j, message := range result.Messages {
+		if !messages[string(message)] {
+			return false
+		}
+
+		valid := bs.signer.isSignatureValid(&Result{
+			Message:   message,
+			Signature: result.Signatures[j],
+		})
+		if !valid {
+			return false
+		}
+	}
+
+	return true
+}
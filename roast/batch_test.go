@@ -0,0 +1,555 @@
+package roast
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"threshold.network/roast/frost"
+	"threshold.network/roast/internal/testutils"
+)
+
+var batchMessages = [][]byte{
+	[]byte("One Ring to rule them all"),
+	[]byte("One Ring to find them"),
+	[]byte("One Ring to bring them all"),
+	[]byte("and in the darkness bind them"),
+}
+
+func (g *group) newBatchCoordinator(t *testing.T) *BatchCoordinator {
+	coordinator, err := NewBatchCoordinator(
+		ciphersuite,
+		g.publicKey,
+		threshold,
+		groupSize,
+		g.verificationShares,
+		batchMessages,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return coordinator
+}
+
+func (g *group) newBatchSigners() []*BatchSigner {
+	signers := make([]*BatchSigner, groupSize)
+	for i, signer := range g.newSigners() {
+		signers[i] = NewBatchSigner(signer, len(batchMessages))
+	}
+	return signers
+}
+
+// runBatchCoordinator delivers all responses to the batch coordinator and all
+// started sessions to the signers until the signatures are produced. The
+// tamper function, if set, can modify every response before it is
+// authenticated again by the signer and delivered.
+func runBatchCoordinator(
+	t *testing.T,
+	coordinator *BatchCoordinator,
+	signers []*BatchSigner,
+	tamper func(response *BatchResponse),
+) []*BatchSession {
+	var queue []*BatchResponse
+	for _, signer := range signers {
+		response, err := signer.Commit()
+		if err != nil {
+			t.Fatal(err)
+		}
+		queue = append(queue, response)
+	}
+
+	return runSessions(
+		t,
+		queue,
+		func(response *BatchResponse) *BatchSession {
+			session, _ := coordinator.Receive(response)
+			return session
+		},
+		func(session *BatchSession) []*BatchResponse {
+			var responses []*BatchResponse
+			for _, signerIndex := range session.Signers() {
+				response, err := signers[signerIndex-1].Sign(session)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if tamper != nil {
+					tamper(response)
+					authenticateBatch(t, signers[signerIndex-1], response, session)
+				}
+				responses = append(responses, response)
+			}
+			return responses
+		},
+		func() bool { return coordinator.Signatures() != nil },
+		50,
+	)
+}
+
+// authenticateBatch authenticates the response modified by the test again,
+// so that the coordinator processes it.
+func authenticateBatch(
+	t *testing.T,
+	signer *BatchSigner,
+	response *BatchResponse,
+	session *BatchSession,
+) {
+	if err := signer.Authenticate(response, session); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertBatchSignatures(t *testing.T, g *group, signatures []*frost.Signature) {
+	testutils.AssertIntsEqual(
+		t,
+		"number of signatures",
+		len(batchMessages),
+		len(signatures),
+	)
+	for j, signature := range signatures {
+		valid, err := ciphersuite.VerifySignature(signature, g.publicKey, batchMessages[j])
+		if err != nil {
+			t.Fatalf("message [%d]: [%v]", j, err)
+		}
+		testutils.AssertBoolsEqual(t, "signature verification result", true, valid)
+	}
+}
+
+func TestBatchCoordinator_HonestSigners(t *testing.T) {
+	g := generateGroup(t)
+	coordinator := g.newBatchCoordinator(t)
+
+	runBatchCoordinator(t, coordinator, g.newBatchSigners(), nil)
+
+	signatures := coordinator.Signatures()
+	if signatures == nil {
+		t.Fatal("expected non-nil signatures")
+	}
+	assertBatchSignatures(t, g, signatures)
+	testutils.AssertIntsEqual(t, "number of malicious signers", 0, len(coordinator.Malicious()))
+}
+
+func TestBatchCoordinator_InvalidShareForOneMessage(t *testing.T) {
+	g := generateGroup(t)
+	coordinator := g.newBatchCoordinator(t)
+
+	// Signer 3 produces an invalid share only for the third message.
+	tamper := func(response *BatchResponse) {
+		if response.SignerIndex == 3 {
+			response.SignatureShares[2] = new(big.Int).Add(
+				response.SignatureShares[2],
+				big.NewInt(1),
+			)
+		}
+	}
+
+	sessions := runBatchCoordinator(t, coordinator, g.newBatchSigners(), tamper)
+
+	signatures := coordinator.Signatures()
+	if signatures == nil {
+		t.Fatal("expected non-nil signatures")
+	}
+	assertBatchSignatures(t, g, signatures)
+
+	if !slices.Equal([]uint64{3}, coordinator.Malicious()) {
+		t.Errorf("unexpected malicious signers: %v", coordinator.Malicious())
+	}
+
+	// Once marked as malicious, the signer is excluded from all messages.
+	excluded := false
+	for _, session := range sessions {
+		included := slices.Contains(session.Signers(), 3)
+		if excluded && included {
+			t.Errorf("malicious signer included in session [%d]", session.ID)
+		}
+		for _, commitments := range session.Commitments {
+			if slices.Contains((&Session{Commitments: commitments}).Signers(), 3) != included {
+				t.Errorf("inconsistent signers of session [%d]", session.ID)
+			}
+		}
+		excluded = excluded || included
+	}
+
+	blames := coordinator.Blames()
+	testutils.AssertIntsEqual(t, "number of blames", 1, len(blames))
+	testutils.AssertStringsEqual(
+		t,
+		"blamed message",
+		string(batchMessages[2]),
+		string(blames[0].Message),
+	)
+	if err := blames[0].Verify(ciphersuite, g.publicKey, g.verificationShares); err != nil {
+		t.Errorf("unexpected blame verification error: [%v]", err)
+	}
+}
+
+func TestNewBatchCoordinator_NoMessages(t *testing.T) {
+	g := generateGroup(t)
+
+	_, err := NewBatchCoordinator(
+		ciphersuite,
+		g.publicKey,
+		threshold,
+		groupSize,
+		g.verificationShares,
+		nil,
+	)
+	if err == nil {
+		t.Fatal("expected non-nil error")
+	}
+	testutils.AssertStringsEqual(t, "constructor error", "no messages to sign", err.Error())
+
+	_, err = NewBatchCoordinatorWithJournal(
+		ciphersuite,
+		g.publicKey,
+		threshold,
+		groupSize,
+		g.verificationShares,
+		[][]byte{},
+		NewMemoryJournal(),
+	)
+	if err == nil {
+		t.Fatal("expected non-nil error")
+	}
+	testutils.AssertStringsEqual(t, "constructor error", "no messages to sign", err.Error())
+}
+
+func TestBatchCoordinator_Journal(t *testing.T) {
+	g := generateGroup(t)
+	journal := NewMemoryJournal()
+
+	newCoordinator := func() *BatchCoordinator {
+		coordinator, err := NewBatchCoordinatorWithJournal(
+			ciphersuite,
+			g.publicKey,
+			threshold,
+			groupSize,
+			g.verificationShares,
+			batchMessages,
+			journal,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return coordinator
+	}
+
+	coordinator := newCoordinator()
+	observer := &recordingObserver{}
+	coordinator.SetObserver(observer)
+
+	// Signer 2 produces an invalid share only for the last message.
+	tamper := func(response *BatchResponse) {
+		if response.SignerIndex == 2 {
+			response.SignatureShares[3] = new(big.Int).Add(
+				response.SignatureShares[3],
+				big.NewInt(1),
+			)
+		}
+	}
+
+	runBatchCoordinator(t, coordinator, g.newBatchSigners(), tamper)
+
+	signatures := coordinator.Signatures()
+	if signatures == nil {
+		t.Fatal("expected non-nil signatures")
+	}
+	assertBatchSignatures(t, g, signatures)
+
+	testutils.AssertIntsEqual(
+		t,
+		"number of signature produced events",
+		1,
+		len(observer.filter(frost.EventSignatureProduced)),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"number of share rejected events",
+		1,
+		len(observer.filter(frost.EventShareRejected)),
+	)
+
+	restored := newCoordinator()
+
+	if !slices.Equal([]uint64{2}, restored.Malicious()) {
+		t.Errorf("unexpected malicious signers: %v", restored.Malicious())
+	}
+	testutils.AssertIntsEqual(
+		t,
+		"sessions started",
+		coordinator.SessionsStarted(),
+		restored.SessionsStarted(),
+	)
+	assertBatchSignatures(t, g, restored.Signatures())
+}
+
+func TestBatchCoordinator_Receive_Failures(t *testing.T) {
+	g := generateGroup(t)
+
+	tests := map[string]struct {
+		tamper      func(response *BatchResponse)
+		expectedErr string
+	}{
+		"too few signature shares": {
+			tamper: func(response *BatchResponse) {
+				response.SignatureShares = response.SignatureShares[1:]
+			},
+			expectedErr: "signer [1] marked as malicious: [signer [1] sent [3] signature shares for [4] messages]",
+		},
+		"too few commitments": {
+			tamper: func(response *BatchResponse) {
+				response.NextCommitments = response.NextCommitments[1:]
+			},
+			expectedErr: "signer [1] marked as malicious: [signer [1] sent [3] nonce commitments for [4] messages]",
+		},
+		"nil signature share": {
+			tamper: func(response *BatchResponse) {
+				response.SignatureShares[1] = nil
+			},
+			expectedErr: "signer [1] marked as malicious: [invalid signature share for message [1] of session [1]: [signature share of signer [1] is nil]]",
+		},
+		"nil commitment": {
+			tamper: func(response *BatchResponse) {
+				response.NextCommitments[3] = nil
+			},
+			expectedErr: "signer [1] marked as malicious: [nonce commitment from signer [1] is nil]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			coordinator := g.newBatchCoordinator(t)
+			signers := g.newBatchSigners()
+
+			var session *BatchSession
+			for _, signer := range signers[:threshold] {
+				response, err := signer.Commit()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if session, err = coordinator.Receive(response); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if session == nil {
+				t.Fatal("expected session to be started")
+			}
+
+			response, err := signers[0].Sign(session)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.tamper(response)
+			authenticateBatch(t, signers[0], response, session)
+
+			_, err = coordinator.Receive(response)
+			if err == nil {
+				t.Fatal("expected non-nil error")
+			}
+			testutils.AssertStringsEqual(t, "receive error", test.expectedErr, err.Error())
+		})
+	}
+}
+
+func TestBatchSigner_Sign_Failures(t *testing.T) {
+	g := generateGroup(t)
+
+	newSession := func(signers []*BatchSigner) *BatchSession {
+		session := &BatchSession{
+			ID:          1,
+			Messages:    batchMessages,
+			Commitments: make([][]*frost.NonceCommitment, len(batchMessages)),
+		}
+		for _, signer := range signers[:threshold] {
+			response, err := signer.Commit()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for j, commitment := range response.NextCommitments {
+				session.Commitments[j] = append(session.Commitments[j], commitment)
+			}
+		}
+		return session
+	}
+
+	tests := map[string]struct {
+		sign        func(signers []*BatchSigner) error
+		expectedErr string
+	}{
+		"nil session": {
+			sign: func(signers []*BatchSigner) error {
+				_, err := signers[0].Sign(nil)
+				return err
+			},
+			expectedErr: "session is nil",
+		},
+		"wrong number of messages": {
+			sign: func(signers []*BatchSigner) error {
+				session := newSession(signers)
+				session.Messages = session.Messages[1:]
+				_, err := signers[0].Sign(session)
+				return err
+			},
+			expectedErr: "session [1] has [3] messages and [4] commitment lists for batch size [4]",
+		},
+		"same commitment for two messages": {
+			sign: func(signers []*BatchSigner) error {
+				session := newSession(signers)
+				session.Commitments[1] = session.Commitments[0]
+				_, err := signers[0].Sign(session)
+				return err
+			},
+			expectedErr: "session [1] uses the same commitment for two messages",
+		},
+		"nonces already spent": {
+			sign: func(signers []*BatchSigner) error {
+				session := newSession(signers)
+				if _, err := signers[0].Sign(session); err != nil {
+					return err
+				}
+				_, err := signers[0].Sign(session)
+				return err
+			},
+			expectedErr: "message [0]: [nonce for the commitment in session [1] has already been spent]",
+		},
+		"no nonce spent if any is unknown": {
+			sign: func(signers []*BatchSigner) error {
+				session := newSession(signers)
+
+				// The commitment for the last message was never generated by
+				// this signer instance.
+				unknown := newSession(g.newBatchSigners())
+				tampered := &BatchSession{
+					ID:          session.ID,
+					Messages:    session.Messages,
+					Commitments: slices.Clone(session.Commitments),
+				}
+				tampered.Commitments[3] = unknown.Commitments[3]
+
+				if _, err := signers[0].Sign(tampered); err == nil {
+					return fmt.Errorf("expected non-nil error")
+				}
+
+				// The nonces for all messages must still be usable.
+				_, err := signers[0].Sign(session)
+				return err
+			},
+		},
+		"no nonce spent if a later commitment list is invalid": {
+			sign: func(signers []*BatchSigner) error {
+				session := newSession(signers)
+
+				tampered := &BatchSession{
+					ID:          session.ID,
+					Messages:    session.Messages,
+					Commitments: slices.Clone(session.Commitments),
+				}
+				tampered.Commitments[2] = tampered.Commitments[2][:threshold-1]
+
+				_, err := signers[0].Sign(tampered)
+				expectedErr := "message [2]: [could not sign in session [1]: " +
+					"[not enough commitments; has [5] for threshold [6]]]"
+				if err == nil || err.Error() != expectedErr {
+					return fmt.Errorf("unexpected error: [%v]", err)
+				}
+
+				// The nonces for all messages must still be usable.
+				_, err = signers[0].Sign(session)
+				return err
+			},
+		},
+		"no nonce spent if signers differ between messages": {
+			sign: func(signers []*BatchSigner) error {
+				session := newSession(signers)
+
+				other, err := signers[threshold].Commit()
+				if err != nil {
+					return err
+				}
+
+				// The last signer of the second message is replaced with
+				// another one.
+				tampered := &BatchSession{
+					ID:          session.ID,
+					Messages:    session.Messages,
+					Commitments: slices.Clone(session.Commitments),
+				}
+				tampered.Commitments[1] = append(
+					slices.Clone(session.Commitments[1][:threshold-1]),
+					other.NextCommitments[1],
+				)
+
+				_, err = signers[0].Sign(tampered)
+				expectedErr := "session [1] has different signers for message [1]"
+				if err == nil || err.Error() != expectedErr {
+					return fmt.Errorf("unexpected error: [%v]", err)
+				}
+
+				// The nonces for all messages must still be usable.
+				_, err = signers[0].Sign(session)
+				return err
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := test.sign(g.newBatchSigners())
+			if test.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: [%v]", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected non-nil error")
+			}
+			testutils.AssertStringsEqual(t, "sign error", test.expectedErr, err.Error())
+		})
+	}
+}
+
+func TestBatch_Network(t *testing.T) {
+	g := generateGroup(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	network := NewNetwork(LinkConfig{
+		Latency: time.Millisecond,
+		Jitter:  5 * time.Millisecond,
+		Reorder: true,
+	})
+	defer network.Close()
+
+	coordinatorTransport, err := network.Connect("coordinator")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addresses, transports := connectSigners(t, network)
+
+	var wg sync.WaitGroup
+	for i, signer := range g.newBatchSigners() {
+		wg.Add(1)
+		go func(signer *BatchSigner, transport Transport) {
+			defer wg.Done()
+			if err := signer.Run(ctx, transport, "coordinator"); err != nil {
+				t.Error(err)
+			}
+		}(signer, transports[uint64(i+1)])
+	}
+
+	signatures, err := g.newBatchCoordinator(t).Run(ctx, coordinatorTransport, addresses)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	network.Close()
+	wg.Wait()
+
+	assertBatchSignatures(t, g, signatures)
+}
@@ -0,0 +1,384 @@
+package roast
+
+import (
+	"fmt"
+
+	"threshold.network/roast/frost"
+)
+
+// BlameOffence is the kind of offence the blamed signer committed.
+type BlameOffence string
+
+const (
+	// BlameInvalidSignatureShare is the offence of sending a signature share
+	// that does not verify against the session commitments, the message,
+	// and the signer's verification share, as specified in [FROST] section
+	// 5.4. Signature Share Verification. The blame record carries all data
+	// needed to repeat the verification.
+	BlameInvalidSignatureShare BlameOffence = "invalid-signature-share"
+
+	// BlameInvalidCommitment is the offence of sending a nonce commitment
+	// that is missing, issued for another signer, or is not a valid
+	// non-identity point on the curve. The blame record carries the
+	// commitment.
+	BlameInvalidCommitment BlameOffence = "invalid-commitment"
+
+	// BlameProtocolViolation is the offence of sending a response the signer
+	// was not asked for, for example, a signature share when no session was
+	// started for the signer or a share for the wrong session. The blame
+	// record carries no evidence other than the reason and cannot be
+	// verified independently.
+	BlameProtocolViolation BlameOffence = "protocol-violation"
+)
+
+// Blame is a record of a single offence for which the [ROAST] coordinator
+// marked the signer as malicious. The record is serializable with
+// encoding/json. Points are serialized with the curve's SerializePoint and
+// scalars with frost.SerializeScalar.
+//
+// Blame records of offences other than BlameProtocolViolation can be
+// verified by anyone knowing the group public key and verification shares,
+// with Verify. Such a record carries the signer's authentication of the
+// response the offending data was sent in, so it proves both that the data
+// is invalid and that the signer sent it. A record of BlameProtocolViolation
+// is only the coordinator's claim.
+type Blame struct {
+	Offence     BlameOffence `json:"offence"`
+	SignerIndex uint64       `json:"signerIndex"`
+	// Reason is the error of the failed verification.
+	Reason string `json:"reason"`
+
+	// SessionID, Message, and Commitments describe the session the signer
+	// responded to. They are empty if the offending data was sent in the
+	// initial response.
+	SessionID   uint64             `json:"sessionId,omitempty"`
+	Message     []byte             `json:"message,omitempty"`
+	Commitments []*BlameCommitment `json:"commitments,omitempty"`
+
+	// SignatureShare and Commitment are the signature share and the next
+	// nonce commitment the signer sent for the message, unless the signer
+	// sent none. For BlameInvalidSignatureShare, the share is invalid. For
+	// BlameInvalidCommitment, the commitment is invalid or missing.
+	SignatureShare []byte           `json:"signatureShare,omitempty"`
+	Commitment     *BlameCommitment `json:"commitment,omitempty"`
+
+	// Authentication is the signer's authentication of all the fields above
+	// except Offence and Reason. It is encoded as the point R followed by
+	// the scalar z and set for all offences other than
+	// BlameProtocolViolation.
+	Authentication []byte `json:"authentication,omitempty"`
+}
+
+// BlameCommitment is a serialized nonce commitment of the blame record.
+type BlameCommitment struct {
+	SignerIndex            uint64 `json:"signerIndex"`
+	HidingNonceCommitment  []byte `json:"hidingNonceCommitment,omitempty"`
+	BindingNonceCommitment []byte `json:"bindingNonceCommitment,omitempty"`
+}
+
+// newBlameCommitment serializes the nonce commitment. The function returns
+// nil for nil commitment. Points that are not valid points on the curve are
+// left empty.
+func newBlameCommitment(
+	curve frost.Curve,
+	commitment *frost.NonceCommitment,
+) *BlameCommitment {
+	if commitment == nil {
+		return nil
+	}
+
+	return &BlameCommitment{
+		SignerIndex:            commitment.SignerIndex(),
+		HidingNonceCommitment:  serializePoint(curve, commitment.HidingNonceCommitment()),
+		BindingNonceCommitment: serializePoint(curve, commitment.BindingNonceCommitment()),
+	}
+}
+
+// serializePoint serializes the point with the curve's SerializePoint. The
+// function returns nil if the point is not a valid point on the curve so that
+// all invalid points are serialized the same way, no matter if their
+// coordinates are set.
+func serializePoint(curve frost.Curve, p *frost.Point) []byte {
+	if p == nil || p.X == nil || p.Y == nil || !curve.IsPointOnCurve(p) {
+		return nil
+	}
+	return curve.SerializePoint(p)
+}
+
+// commitment deserializes the nonce commitment. The function returns nil if
+// any of the points is not a valid non-identity point on the curve.
+func (bc *BlameCommitment) commitment(curve frost.Curve) *frost.NonceCommitment {
+	hiding := curve.DeserializePoint(bc.HidingNonceCommitment)
+	binding := curve.DeserializePoint(bc.BindingNonceCommitment)
+	if hiding == nil || binding == nil {
+		return nil
+	}
+	return frost.NewNonceCommitment(bc.SignerIndex, hiding, binding)
+}
+
+// newSignatureShareBlame creates the blame record of the invalid signature
+// share the signer sent for the j-th message of the session.
+func newSignatureShareBlame(
+	curve frost.Curve,
+	response *BatchResponse,
+	j int,
+	session *BatchSession,
+	cause error,
+) *Blame {
+	return newResponseBlame(
+		BlameInvalidSignatureShare,
+		curve,
+		response,
+		j,
+		session,
+		cause,
+	)
+}
+
+// newCommitmentBlame creates the blame record of the invalid nonce
+// commitment the signer sent for the j-th message. The session is nil if the
+// commitment was sent in the initial response.
+func newCommitmentBlame(
+	curve frost.Curve,
+	response *BatchResponse,
+	j int,
+	session *BatchSession,
+	cause error,
+) *Blame {
+	return newResponseBlame(
+		BlameInvalidCommitment,
+		curve,
+		response,
+		j,
+		session,
+		cause,
+	)
+}
+
+// newResponseBlame creates the blame record of the offence the signer
+// committed in its authenticated response for the j-th message. The record
+// holds exactly the data the signer authenticated for the message.
+func newResponseBlame(
+	offence BlameOffence,
+	curve frost.Curve,
+	response *BatchResponse,
+	j int,
+	session *BatchSession,
+	cause error,
+) *Blame {
+	blame := &Blame{
+		Offence:        offence,
+		SignerIndex:    response.SignerIndex,
+		Reason:         cause.Error(),
+		SessionID:      response.SessionID,
+		Authentication: encodeAuthentication(curve, response.Authentications[j]),
+	}
+
+	if session != nil {
+		blame.Message = session.Messages[j]
+		for _, commitment := range session.Commitments[j] {
+			blame.Commitments = append(
+				blame.Commitments,
+				newBlameCommitment(curve, commitment),
+			)
+		}
+	}
+
+	if j < len(response.SignatureShares) && response.SignatureShares[j] != nil {
+		blame.SignatureShare = frost.SerializeScalar(curve, response.SignatureShares[j])
+	}
+
+	if j < len(response.NextCommitments) {
+		blame.Commitment = newBlameCommitment(curve, response.NextCommitments[j])
+	}
+
+	return blame
+}
+
+// newProtocolViolationBlame creates the blame record of the protocol
+// violation committed by the signer.
+func newProtocolViolationBlame(signerIndex uint64, cause error) *Blame {
+	return &Blame{
+		Offence:     BlameProtocolViolation,
+		SignerIndex: signerIndex,
+		Reason:      cause.Error(),
+	}
+}
+
+// Verify independently checks the blame record against the group public key
+// and verification shares of the group. The function returns nil if the
+// record proves the offence and an error explaining why it does not
+// otherwise.
+func (b *Blame) Verify(
+	ciphersuite frost.Ciphersuite,
+	publicKey *frost.Point,
+	verificationShares map[uint64]*frost.Point,
+) error {
+	switch b.Offence {
+	case BlameInvalidSignatureShare:
+		if err := b.verifyAuthentication(ciphersuite, verificationShares); err != nil {
+			return err
+		}
+		return b.verifySignatureShare(ciphersuite, publicKey, verificationShares)
+	case BlameInvalidCommitment:
+		if err := b.verifyAuthentication(ciphersuite, verificationShares); err != nil {
+			return err
+		}
+		return b.verifyCommitment(ciphersuite.Curve())
+	case BlameProtocolViolation:
+		return fmt.Errorf(
+			"offence [%s] carries no evidence that could be verified",
+			b.Offence,
+		)
+	default:
+		return fmt.Errorf("unknown offence [%s]", b.Offence)
+	}
+}
+
+// verifyAuthentication verifies the signer authenticated the data of the
+// blame record, so that the record could not be fabricated by anyone not
+// knowing the signer's secret key share.
+func (b *Blame) verifyAuthentication(
+	ciphersuite frost.Ciphersuite,
+	verificationShares map[uint64]*frost.Point,
+) error {
+	verificationShare, ok := verificationShares[b.SignerIndex]
+	if !ok {
+		return fmt.Errorf(
+			"verification share of signer [%d] is unknown",
+			b.SignerIndex,
+		)
+	}
+
+	if len(b.Authentication) == 0 {
+		return fmt.Errorf(
+			"blame record carries no authentication of signer [%d]",
+			b.SignerIndex,
+		)
+	}
+
+	authentication, err := decodeAuthentication(
+		ciphersuite.Curve(),
+		b.Authentication,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"invalid authentication of signer [%d]: [%v]",
+			b.SignerIndex,
+			err,
+		)
+	}
+
+	return frost.VerifyAuthentication(
+		ciphersuite,
+		b.SignerIndex,
+		verificationShare,
+		authenticatedData(
+			b.SignerIndex,
+			b.SessionID,
+			b.Message,
+			b.Commitments,
+			b.SignatureShare,
+			b.Commitment,
+		),
+		authentication,
+	)
+}
+
+func (b *Blame) verifySignatureShare(
+	ciphersuite frost.Ciphersuite,
+	publicKey *frost.Point,
+	verificationShares map[uint64]*frost.Point,
+) error {
+	curve := ciphersuite.Curve()
+
+	if b.SignatureShare == nil {
+		return fmt.Errorf("signature share is missing")
+	}
+
+	// The signer authenticated the share, so a share that is not even
+	// a scalar proves the offence.
+	share, err := frost.DeserializeScalar(curve, b.SignatureShare)
+	if err != nil {
+		return nil
+	}
+
+	// The session commitments are validated before the share is verified so
+	// that an invalid commitment list is not mistaken for an invalid share.
+	included := false
+	commitments := make([]*frost.NonceCommitment, len(b.Commitments))
+	for i, bc := range b.Commitments {
+		if bc == nil {
+			return fmt.Errorf("session commitment [%d] is missing", i)
+		}
+		if i > 0 && bc.SignerIndex <= b.Commitments[i-1].SignerIndex {
+			return fmt.Errorf(
+				"session commitments are not sorted by signer identifier",
+			)
+		}
+		if _, ok := verificationShares[bc.SignerIndex]; !ok {
+			return fmt.Errorf(
+				"session commitment [%d] is issued for unknown signer [%d]",
+				i,
+				bc.SignerIndex,
+			)
+		}
+		commitments[i] = bc.commitment(curve)
+		if commitments[i] == nil {
+			return fmt.Errorf(
+				"session commitment [%d] is not a valid non-identity point "+
+					"on the curve",
+				i,
+			)
+		}
+		if bc.SignerIndex == b.SignerIndex {
+			included = true
+		}
+	}
+	if !included {
+		return fmt.Errorf(
+			"session commitments do not include commitment of signer [%d]",
+			b.SignerIndex,
+		)
+	}
+
+	coordinator := frost.NewCoordinator(
+		ciphersuite,
+		publicKey,
+		len(commitments),
+		len(verificationShares),
+		verificationShares,
+	)
+
+	_, err = coordinator.VerifySignatureShare(
+		b.SignerIndex,
+		share,
+		commitments,
+		b.Message,
+	)
+	if err == nil {
+		return fmt.Errorf(
+			"signature share of signer [%d] is valid",
+			b.SignerIndex,
+		)
+	}
+
+	return nil
+}
+
+func (b *Blame) verifyCommitment(curve frost.Curve) error {
+	if b.Commitment == nil {
+		// The signer authenticated a response with no commitment at all.
+		return nil
+	}
+
+	if b.Commitment.SignerIndex != b.SignerIndex {
+		return nil
+	}
+
+	if b.Commitment.commitment(curve) == nil {
+		return nil
+	}
+
+	return fmt.Errorf("nonce commitment of signer [%d] is valid", b.SignerIndex)
+}
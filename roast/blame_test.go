@@ -0,0 +1,297 @@
+package roast
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"threshold.network/roast/frost"
+	"threshold.network/roast/internal/testutils"
+)
+
+func TestCoordinator_Blames(t *testing.T) {
+	g := generateGroup(t)
+	coordinator := g.newCoordinator()
+
+	signers := newFrostSigners(t, g)
+	signers[1].corruptShares = true
+	signers[6].corruptShares = true
+
+	runCoordinator(t, coordinator, signers, 50)
+
+	blames := coordinator.Blames()
+	testutils.AssertIntsEqual(t, "number of blames", 2, len(blames))
+
+	for _, blame := range blames {
+		testutils.AssertStringsEqual(
+			t,
+			"offence",
+			string(BlameInvalidSignatureShare),
+			string(blame.Offence),
+		)
+
+		// The blame record must be verifiable by anyone after it is
+		// serialized and sent over the wire.
+		encoded, err := json.Marshal(blame)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := &Blame{}
+		if err := json.Unmarshal(encoded, decoded); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := decoded.Verify(ciphersuite, g.publicKey, g.verificationShares); err != nil {
+			t.Errorf("unexpected blame verification error: [%v]", err)
+		}
+	}
+}
+
+func TestBlame_Verify(t *testing.T) {
+	g := generateGroup(t)
+	curve := ciphersuite.Curve()
+	signers := newFrostSigners(t, g)
+
+	session := &Session{ID: 1, Message: message}
+	for _, s := range signers[:threshold] {
+		session.Commitments = append(session.Commitments, s.initial(t).NextCommitment)
+	}
+	response := signers[0].sign(t, session)
+
+	offCurve := frost.NewNonceCommitment(
+		1,
+		&frost.Point{X: big.NewInt(1), Y: big.NewInt(2)},
+		&frost.Point{X: big.NewInt(3), Y: big.NewInt(4)},
+	)
+
+	// newBlame returns the blame record of the response to the session,
+	// authenticated by signer 1. The session is nil for the initial
+	// response.
+	newBlame := func(
+		offence BlameOffence,
+		response *Response,
+		session *Session,
+	) *Blame {
+		signers[0].authenticate(t, response, session)
+
+		var batch *BatchSession
+		if session != nil {
+			batch = session.batch()
+		}
+		return newResponseBlame(
+			offence,
+			curve,
+			response.batch(),
+			0,
+			batch,
+			fmt.Errorf("invalid"),
+		)
+	}
+
+	// shareBlame returns the blame record of the signature share sent by
+	// signer 1 in the session.
+	shareBlame := func(share *big.Int) *Blame {
+		return newBlame(BlameInvalidSignatureShare, &Response{
+			SignerIndex:    1,
+			SessionID:      session.ID,
+			SignatureShare: share,
+			NextCommitment: response.NextCommitment,
+		}, session)
+	}
+
+	// authenticate authenticates the blame record modified by the test as
+	// signer 1.
+	authenticate := func(blame *Blame) *Blame {
+		authentication, err := signers[0].signer.Authenticate(authenticatedData(
+			blame.SignerIndex,
+			blame.SessionID,
+			blame.Message,
+			blame.Commitments,
+			blame.SignatureShare,
+			blame.Commitment,
+		))
+		if err != nil {
+			t.Fatal(err)
+		}
+		blame.Authentication = encodeAuthentication(curve, authentication)
+		return blame
+	}
+
+	invalidShare := new(big.Int).Add(response.SignatureShare, big.NewInt(1))
+
+	tests := map[string]struct {
+		blame       func() *Blame
+		expectedErr string
+	}{
+		"invalid signature share": {
+			blame: func() *Blame {
+				return shareBlame(invalidShare)
+			},
+		},
+		"signature share not a valid scalar": {
+			blame: func() *Blame {
+				blame := shareBlame(response.SignatureShare)
+				blame.SignatureShare = curve.Order().Bytes()
+				return authenticate(blame)
+			},
+		},
+		"valid signature share": {
+			blame: func() *Blame {
+				return shareBlame(response.SignatureShare)
+			},
+			expectedErr: "signature share of signer [1] is valid",
+		},
+		"unknown verification share": {
+			blame: func() *Blame {
+				blame := shareBlame(invalidShare)
+				blame.SignerIndex = uint64(groupSize + 1)
+				return blame
+			},
+			expectedErr: "verification share of signer [11] is unknown",
+		},
+		"missing signature share": {
+			blame: func() *Blame {
+				blame := shareBlame(response.SignatureShare)
+				blame.SignatureShare = nil
+				return authenticate(blame)
+			},
+			expectedErr: "signature share is missing",
+		},
+		"unsorted session commitments": {
+			blame: func() *Blame {
+				blame := shareBlame(response.SignatureShare)
+				blame.Commitments[0], blame.Commitments[1] =
+					blame.Commitments[1], blame.Commitments[0]
+				return authenticate(blame)
+			},
+			expectedErr: "session commitments are not sorted by signer identifier",
+		},
+		"off-curve session commitment": {
+			blame: func() *Blame {
+				blame := shareBlame(response.SignatureShare)
+				blame.Commitments[2] = newBlameCommitment(
+					curve,
+					frost.NewNonceCommitment(
+						3,
+						offCurve.HidingNonceCommitment(),
+						offCurve.BindingNonceCommitment(),
+					),
+				)
+				return authenticate(blame)
+			},
+			expectedErr: "session commitment [2] is not a valid non-identity point on the curve",
+		},
+		"commitment of blamed signer not in session": {
+			blame: func() *Blame {
+				blame := shareBlame(response.SignatureShare)
+				blame.Commitments = blame.Commitments[1:]
+				return authenticate(blame)
+			},
+			expectedErr: "session commitments do not include commitment of signer [1]",
+		},
+		"off-curve commitment": {
+			blame: func() *Blame {
+				return newBlame(
+					BlameInvalidCommitment,
+					&Response{SignerIndex: 1, NextCommitment: offCurve},
+					nil,
+				)
+			},
+		},
+		"commitment of another signer": {
+			blame: func() *Blame {
+				return newBlame(
+					BlameInvalidCommitment,
+					&Response{SignerIndex: 1, NextCommitment: session.Commitments[1]},
+					nil,
+				)
+			},
+		},
+		"nil commitment": {
+			blame: func() *Blame {
+				return newBlame(BlameInvalidCommitment, &Response{SignerIndex: 1}, nil)
+			},
+		},
+		"nil commitment in session": {
+			blame: func() *Blame {
+				return newBlame(BlameInvalidCommitment, &Response{
+					SignerIndex:    1,
+					SessionID:      session.ID,
+					SignatureShare: response.SignatureShare,
+				}, session)
+			},
+		},
+		"valid commitment": {
+			blame: func() *Blame {
+				return newBlame(
+					BlameInvalidCommitment,
+					&Response{SignerIndex: 1, NextCommitment: session.Commitments[0]},
+					nil,
+				)
+			},
+			expectedErr: "nonce commitment of signer [1] is valid",
+		},
+		"no authentication": {
+			blame: func() *Blame {
+				blame := newBlame(BlameInvalidCommitment, &Response{SignerIndex: 1}, nil)
+				blame.Authentication = nil
+				return blame
+			},
+			expectedErr: "blame record carries no authentication of signer [1]",
+		},
+		"malformed authentication": {
+			blame: func() *Blame {
+				blame := shareBlame(invalidShare)
+				blame.Authentication = blame.Authentication[1:]
+				return blame
+			},
+			expectedErr: "invalid authentication of signer [1]: " +
+				"[authentication must be [97] bytes long; has [96]]",
+		},
+		"blame of another signer": {
+			blame: func() *Blame {
+				blame := newBlame(BlameInvalidCommitment, &Response{SignerIndex: 1}, nil)
+				blame.SignerIndex = 2
+				return blame
+			},
+			expectedErr: "authentication of signer [2] is invalid",
+		},
+		"share of another session": {
+			blame: func() *Blame {
+				blame := shareBlame(invalidShare)
+				blame.Message = []byte("another message")
+				return blame
+			},
+			expectedErr: "authentication of signer [1] is invalid",
+		},
+		"protocol violation": {
+			blame: func() *Blame {
+				return newProtocolViolationBlame(1, fmt.Errorf("invalid"))
+			},
+			expectedErr: "offence [protocol-violation] carries no evidence that could be verified",
+		},
+		"unknown offence": {
+			blame: func() *Blame {
+				return &Blame{Offence: "unknown", SignerIndex: 1}
+			},
+			expectedErr: "unknown offence [unknown]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := test.blame().Verify(ciphersuite, g.publicKey, g.verificationShares)
+			if test.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: [%v]", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected non-nil error")
+			}
+			testutils.AssertStringsEqual(t, "verification error", test.expectedErr, err.Error())
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package roast
+
+import (
+	"context"
+	"fmt"
+
+	"threshold.network/roast/frost"
+)
+
+// Coordinator represents the coordinator of the [ROAST] protocol. The
+// coordinator wraps [FROST] coordinator and runs [FROST] signing sessions
+// in an asynchronous way, starting a new session every time a threshold of
+// signers responded with a fresh nonce commitment. Signers that provided an
+// invalid signature share are marked as malicious and never included in any
+// new session.
+//
+// The coordinator is a state machine, it does not perform any network
+// communication on its own. All signer responses should be passed to the
+// Receive function and all sessions returned from that function should be
+// delivered to the signers included in the session.
+type Coordinator struct {
+	machine
+}
+
+// NewCoordinator creates a new [ROAST] Coordinator instance for signing the
+// given message. Verification shares of all group members, indexed by the
+// signer identifier, are required to verify signature shares and identify
+// malicious signers.
+func NewCoordinator(
+	ciphersuite frost.Ciphersuite,
+	publicKey *frost.Point,
+	threshold int,
+	groupSize int,
+	verificationShares map[uint64]*frost.Point,
+	message []byte,
+) *Coordinator {
+	return &Coordinator{
+		machine: newMachine(
+			ciphersuite,
+			publicKey,
+			threshold,
+			groupSize,
+			verificationShares,
+			[][]byte{message},
+		),
+	}
+}
+
+// NewCoordinatorWithJournal creates a new [ROAST] Coordinator instance for
+// signing the given message that appends every signer response that may
+// change its state to the journal. If the journal is not empty, the
+// coordinator's state, including the responsive set, the malicious set, and
+// sessions started, is restored from the journal before the function
+// returns.
+func NewCoordinatorWithJournal(
+	ciphersuite frost.Ciphersuite,
+	publicKey *frost.Point,
+	threshold int,
+	groupSize int,
+	verificationShares map[uint64]*frost.Point,
+	message []byte,
+	journal Journal,
+) (*Coordinator, error) {
+	c := NewCoordinator(
+		ciphersuite,
+		publicKey,
+		threshold,
+		groupSize,
+		verificationShares,
+		message,
+	)
+
+	if err := c.restore(journal); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Receive processes the response from the signer. If the response makes the
+// responsive set reach the threshold, a new session is started and returned
+// from the function. The session should be delivered to all signers whose
+// commitments are on the session's commitment list.
+//
+// The function returns an error if the response was rejected. A response not
+// authenticated by the signer, see Response.Authentication, is rejected
+// without changing the state. If the response proves the signer misbehaved,
+// the signer is marked as malicious and all future responses from it are
+// rejected. The function returns an error when there are more malicious
+// signers than the protocol can tolerate, that is, more than
+// groupSize - threshold. In this case, it is no longer possible to produce
+// a signature.
+//
+// A signer that restarted sends the initial commitment again. If the signer
+// is not asked to sign in any session, the commitment replaces the one sent
+// before. Otherwise, the commitment is rejected without marking the signer as
+// malicious and the session should be delivered to the signer again.
+//
+// Once a valid signature is produced, it is available with the Signature
+// function and all further responses are rejected.
+func (c *Coordinator) Receive(response *Response) (*Session, error) {
+	var batch *BatchResponse
+	if response != nil {
+		batch = response.batch()
+	}
+
+	session, err := c.receive(batch)
+	if session == nil {
+		return nil, err
+	}
+
+	return session.session(0), err
+}
+
+// Signature returns the signature produced by the coordinator or nil if the
+// signature has not been produced yet.
+func (c *Coordinator) Signature() *frost.Signature {
+	return c.signatures[0]
+}
+
+// validateCommitment ensures the nonce commitment received from the signer
+// is present, belongs to the signer, and both commitment points are valid,
+// non-identity points on the curve.
+func validateCommitment(
+	curve frost.Curve,
+	signerIndex uint64,
+	commitment *frost.NonceCommitment,
+) error {
+	if commitment == nil {
+		return fmt.Errorf("nonce commitment from signer [%d] is nil", signerIndex)
+	}
+
+	if commitment.SignerIndex() != signerIndex {
+		return fmt.Errorf(
+			"nonce commitment from signer [%d] is issued for signer [%d]",
+			signerIndex,
+			commitment.SignerIndex(),
+		)
+	}
+
+	hiding := commitment.HidingNonceCommitment()
+	binding := commitment.BindingNonceCommitment()
+	if hiding == nil || !curve.IsPointOnCurve(hiding) ||
+		binding == nil || !curve.IsPointOnCurve(binding) {
+		return fmt.Errorf(
+			"nonce commitment from signer [%d] is not a valid "+
+				"non-identity point on the curve",
+			signerIndex,
+		)
+	}
+
+	return nil
+}
+
+// Run executes the coordinator over the transport until the signature is
+// produced. Signer addresses, indexed by the signer identifier, are used to
+// deliver sessions and to authenticate responses: a response is processed
+// only if it was sent from the address of the signer it claims to come from.
+//
+// In the semi-interactive mode of [ROAST], several coordinators run at the
+// same time and the addresses of all other coordinators should be passed as
+// peers. The first valid signature ends the signing: the coordinator that
+// produced it sends it to all signers and peers, and every coordinator that
+// receives a valid signature for its message returns it.
+//
+// If the coordinator was restored from the journal, sessions started before
+// the restart are sent again to all signers that have not responded yet. A
+// session is also sent again to the signer that restarted and sent the
+// initial commitment again instead of responding to the session.
+//
+// The function returns an error if there are too many malicious signers to
+// produce a signature or if the transport was closed before the signature
+// was produced. If the context is done before the signature was produced, the
+// function returns *InterruptedError with the partial result of the signing.
+func (c *Coordinator) Run(
+	ctx context.Context,
+	transport Transport,
+	signers map[uint64]Address,
+	peers ...Address,
+) (*frost.Signature, error) {
+	signatures, err := c.run(ctx, transport, signers, peers, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return signatures[0], nil
+}
+
+func (c *Coordinator) decodeResponse(message Message) (*BatchResponse, bool) {
+	response, ok := message.(*Response)
+	if !ok || response == nil {
+		return nil, false
+	}
+	return response.batch(), true
+}
+
+func (c *Coordinator) encodeSession(session *BatchSession) Message {
+	return session.session(0)
+}
+
+func (c *Coordinator) encodeResult(signatures []*frost.Signature) Message {
+	return &Result{Message: c.messages[0], Signature: signatures[0]}
+}
+
+func (c *Coordinator) decodeResult(message Message) ([]*frost.Signature, bool) {
+	result, ok := message.(*Result)
+	if !ok || result == nil {
+		return nil, false
+	}
+	return []*frost.Signature{result.Signature}, true
+}
+
+// CoordinatorsRequired returns the number of coordinators required in the
+// semi-interactive mode of [ROAST] so that at least one of them is honest,
+// assuming no more than groupSize - threshold group members are malicious.
+func CoordinatorsRequired(groupSize, threshold int) int {
+	return groupSize - threshold + 1
+}
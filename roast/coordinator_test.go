@@ -0,0 +1,554 @@
+package roast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"threshold.network/roast/frost"
+	"threshold.network/roast/internal/testutils"
+)
+
+// frostSigner is a minimal signer used to test the coordinator in isolation.
+// It responds to sessions with [FROST] signature shares and fresh commitments.
+type frostSigner struct {
+	signer     *frost.Signer
+	index      uint64
+	commitment *frost.NonceCommitment
+
+	// corruptShares makes the signer respond with invalid signature shares.
+	corruptShares bool
+}
+
+func newFrostSigners(t *testing.T, g *group) []*frostSigner {
+	signers := make([]*frostSigner, groupSize)
+	for i := range signers {
+		signerIndex := uint64(i + 1)
+		signers[i] = &frostSigner{
+			signer: frost.NewSigner(
+				ciphersuite,
+				signerIndex,
+				g.publicKey,
+				threshold,
+				groupSize,
+				g.secretKeyShares[signerIndex],
+			),
+			index: signerIndex,
+		}
+	}
+	return signers
+}
+
+func (fs *frostSigner) initial(t *testing.T) *Response {
+	commitment, err := fs.signer.Round1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs.commitment = commitment
+	return fs.authenticate(
+		t,
+		&Response{SignerIndex: fs.index, NextCommitment: commitment},
+		nil,
+	)
+}
+
+func (fs *frostSigner) sign(t *testing.T, session *Session) *Response {
+	share, err := fs.signer.Round2(session.Message, fs.commitment, session.Commitments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fs.corruptShares {
+		share = new(big.Int).Add(share, big.NewInt(1))
+	}
+
+	commitment, err := fs.signer.Round1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs.commitment = commitment
+
+	return fs.authenticate(t, &Response{
+		SignerIndex:    fs.index,
+		SessionID:      session.ID,
+		SignatureShare: share,
+		NextCommitment: commitment,
+	}, session)
+}
+
+// authenticate sets the authentication of the response to the session the
+// same way the Signer does, no matter if the response is valid. The session
+// is nil for the initial response.
+func (fs *frostSigner) authenticate(
+	t *testing.T,
+	response *Response,
+	session *Session,
+) *Response {
+	var batch *BatchSession
+	if session != nil {
+		batch = session.batch()
+	}
+
+	authentication, err := fs.signer.Authenticate(
+		responseData(ciphersuite.Curve(), response.batch(), 0, batch),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response.Authentication = authentication
+	return response
+}
+
+// signSession returns the responses of all signers included in the session.
+func signSession(t *testing.T, signers []*frostSigner, session *Session) []*Response {
+	var responses []*Response
+	for _, signerIndex := range session.Signers() {
+		responses = append(responses, signers[signerIndex-1].sign(t, session))
+	}
+	return responses
+}
+
+// runCoordinator delivers all responses to the coordinator and all started
+// sessions to the signers until the signature is produced or the maximum
+// number of sessions is reached.
+func runCoordinator(
+	t *testing.T,
+	coordinator *Coordinator,
+	signers []*frostSigner,
+	maxSessions int,
+) {
+	var queue []*Response
+	for _, s := range signers {
+		queue = append(queue, s.initial(t))
+	}
+
+	runSessions(
+		t,
+		queue,
+		func(response *Response) *Session {
+			session, _ := coordinator.Receive(response)
+			return session
+		},
+		func(session *Session) []*Response {
+			return signSession(t, signers, session)
+		},
+		func() bool { return coordinator.Signature() != nil },
+		maxSessions,
+	)
+}
+
+func TestCoordinator_HonestSigners(t *testing.T) {
+	g := generateGroup(t)
+	coordinator := g.newCoordinator()
+
+	runCoordinator(t, coordinator, newFrostSigners(t, g), 50)
+
+	signature := coordinator.Signature()
+	if signature == nil {
+		t.Fatal("expected non-nil signature")
+	}
+
+	valid, err := ciphersuite.VerifySignature(signature, g.publicKey, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "signature verification result", true, valid)
+	testutils.AssertIntsEqual(t, "number of malicious signers", 0, len(coordinator.Malicious()))
+}
+
+func TestCoordinator_InvalidSignatureShares(t *testing.T) {
+	g := generateGroup(t)
+	coordinator := g.newCoordinator()
+
+	signers := newFrostSigners(t, g)
+	signers[1].corruptShares = true
+	signers[6].corruptShares = true
+
+	runCoordinator(t, coordinator, signers, 50)
+
+	signature := coordinator.Signature()
+	if signature == nil {
+		t.Fatal("expected non-nil signature")
+	}
+
+	valid, err := ciphersuite.VerifySignature(signature, g.publicKey, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "signature verification result", true, valid)
+
+	malicious := coordinator.Malicious()
+	if !slices.Equal([]uint64{2, 7}, malicious) {
+		t.Errorf("unexpected malicious signers\nexpected: [2 7]\nactual:   %v", malicious)
+	}
+}
+
+func TestCoordinator_TooManyMalicious(t *testing.T) {
+	g := generateGroup(t)
+	coordinator := g.newCoordinator()
+
+	signers := newFrostSigners(t, g)
+	for i := 0; i <= groupSize-threshold; i++ {
+		response := signers[i].initial(t)
+		response.NextCommitment = signers[i+1].initial(t).NextCommitment
+		_, err := coordinator.Receive(signers[i].authenticate(t, response, nil))
+		if err == nil {
+			t.Fatal("expected non-nil error")
+		}
+	}
+
+	_, err := coordinator.Receive(signers[groupSize-1].initial(t))
+	testutils.AssertStringsEqual(
+		t,
+		"receive error",
+		"too many malicious signers; has [5] for group size [10] and threshold [6]",
+		err.Error(),
+	)
+}
+
+func TestCoordinator_Receive_Failures(t *testing.T) {
+	g := generateGroup(t)
+	signers := newFrostSigners(t, g)
+
+	tests := map[string]struct {
+		responses   func() []*Response
+		expectedErr string
+	}{
+		"nil response": {
+			responses: func() []*Response {
+				return []*Response{nil}
+			},
+			expectedErr: "response is nil",
+		},
+		"unknown signer": {
+			responses: func() []*Response {
+				response := signers[0].initial(t)
+				response.SignerIndex = uint64(groupSize + 1)
+				return []*Response{response}
+			},
+			expectedErr: "unknown signer [11]",
+		},
+		"nil commitment": {
+			responses: func() []*Response {
+				return []*Response{signers[0].authenticate(t, &Response{SignerIndex: 1}, nil)}
+			},
+			expectedErr: "signer [1] marked as malicious: [nonce commitment from signer [1] is nil]",
+		},
+		"commitment of another signer": {
+			responses: func() []*Response {
+				response := signers[0].initial(t)
+				response.SignerIndex = 2
+				return []*Response{signers[1].authenticate(t, response, nil)}
+			},
+			expectedErr: "signer [2] marked as malicious: [nonce commitment from signer [2] is issued for signer [1]]",
+		},
+		"response not authenticated": {
+			responses: func() []*Response {
+				response := signers[0].initial(t)
+				response.Authentication = nil
+				return []*Response{response}
+			},
+			expectedErr: "response from signer [1] is not authenticated: [message [0]: " +
+				"[authentication of signer [1] is malformed]]",
+		},
+		"response authenticated by another signer": {
+			responses: func() []*Response {
+				response := signers[0].initial(t)
+				response.SignerIndex = 2
+				return []*Response{response}
+			},
+			expectedErr: "response from signer [2] is not authenticated: [message [0]: " +
+				"[authentication of signer [2] is invalid]]",
+		},
+		"response modified after authentication": {
+			responses: func() []*Response {
+				response := signers[0].initial(t)
+				response.NextCommitment = signers[0].initial(t).NextCommitment
+				return []*Response{response}
+			},
+			expectedErr: "response from signer [1] is not authenticated: [message [0]: " +
+				"[authentication of signer [1] is invalid]]",
+		},
+		"response for unknown session": {
+			responses: func() []*Response {
+				response := signers[0].initial(t)
+				response.SessionID = 1
+				response.SignatureShare = big.NewInt(1)
+				return []*Response{signers[0].authenticate(t, response, nil)}
+			},
+			expectedErr: "response from signer [1] is not authenticated: [session [1] is unknown]",
+		},
+		"initial commitment in session": {
+			responses: func() []*Response {
+				var responses []*Response
+				for _, signer := range signers[:threshold] {
+					responses = append(responses, signer.initial(t))
+				}
+				return append(responses, signers[0].initial(t))
+			},
+			expectedErr: "signer [1] sent the initial commitment but is asked to sign in session [1]",
+		},
+		"unexpected signature share": {
+			responses: func() []*Response {
+				response := signers[0].initial(t)
+				response.SignatureShare = big.NewInt(1)
+				return []*Response{signers[0].authenticate(t, response, nil)}
+			},
+			expectedErr: "signer [1] marked as malicious: [unexpected signature share from signer [1]]",
+		},
+		"signature share not a scalar": {
+			responses: func() []*Response {
+				response := signers[0].initial(t)
+				response.SignatureShare = ciphersuite.Curve().Order()
+				return []*Response{response}
+			},
+			expectedErr: "signature share from signer [1] is not a valid scalar",
+		},
+		"response from malicious signer": {
+			responses: func() []*Response {
+				return []*Response{
+					signers[0].authenticate(t, &Response{SignerIndex: 1}, nil),
+					signers[0].initial(t),
+				}
+			},
+			expectedErr: "signer [1] is marked as malicious",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			coordinator := g.newCoordinator()
+
+			var err error
+			for _, response := range test.responses() {
+				_, err = coordinator.Receive(response)
+			}
+
+			if err == nil {
+				t.Fatal("expected non-nil error")
+			}
+			testutils.AssertStringsEqual(t, "receive error", test.expectedErr, err.Error())
+		})
+	}
+}
+
+func TestCoordinator_Receive_Restarted(t *testing.T) {
+	g := generateGroup(t)
+	coordinator := g.newCoordinator()
+	signers := newFrostSigners(t, g)
+
+	// The first signer restarts before the session is started and sends
+	// the initial commitment again. The commitment replaces the previous one.
+	for _, response := range []*Response{
+		signers[0].initial(t),
+		signers[1].initial(t),
+		signers[0].initial(t),
+	} {
+		if _, err := coordinator.Receive(response); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var session *Session
+	for _, signer := range signers[2:threshold] {
+		var err error
+		session, err = coordinator.Receive(signer.initial(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if session == nil {
+		t.Fatal("expected a session started")
+	}
+	testutils.AssertIntsEqual(t, "number of signers", threshold, len(session.Signers()))
+	testutils.AssertBoolsEqual(
+		t,
+		"replaced commitment in session",
+		true,
+		slices.Contains(session.Commitments, signers[0].commitment),
+	)
+
+	// The first signer restarts again once asked to sign. The commitment is
+	// rejected and the signer can still respond to the session.
+	response := signers[0].sign(t, session)
+	if _, err := coordinator.Receive(signers[0].initial(t)); err == nil {
+		t.Fatal("expected non-nil error")
+	}
+	if _, err := coordinator.Receive(response); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "number of malicious signers", 0, len(coordinator.Malicious()))
+}
+
+func TestCoordinator_SemiInteractive(t *testing.T) {
+	g := generateGroup(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	network := NewNetwork(LinkConfig{
+		Latency: time.Millisecond,
+		Jitter:  5 * time.Millisecond,
+		Reorder: true,
+	})
+	defer network.Close()
+
+	coordinatorsRequired := CoordinatorsRequired(groupSize, threshold)
+	testutils.AssertIntsEqual(t, "coordinators required", 5, coordinatorsRequired)
+
+	coordinators := make([]Address, coordinatorsRequired)
+	coordinatorTransports := make([]Transport, coordinatorsRequired)
+	for i := range coordinators {
+		var err error
+		coordinators[i] = Address(fmt.Sprintf("coordinator-%d", i+1))
+		coordinatorTransports[i], err = network.Connect(coordinators[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	signers := make(map[uint64]Address, groupSize)
+	for i, signer := range g.newSigners() {
+		signerIndex := uint64(i + 1)
+		signers[signerIndex] = Address(fmt.Sprintf("signer-%d", signerIndex))
+		transport, err := network.Connect(signers[signerIndex])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		go func(signer *Signer, transport Transport) {
+			// Signers are not awaited; they may be stopped by the context
+			// cancelled when the test ends.
+			err := signer.Run(ctx, transport, coordinators...)
+			if err != nil && ctx.Err() == nil {
+				t.Error(err)
+			}
+		}(signer, transport)
+	}
+
+	// The first two coordinators crashed and never run.
+	var wg sync.WaitGroup
+	signatures := make([]*frost.Signature, coordinatorsRequired)
+	for i := 2; i < coordinatorsRequired; i++ {
+		peers := slices.Delete(slices.Clone(coordinators), i, i+1)
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			signature, err := g.newCoordinator().Run(
+				ctx,
+				coordinatorTransports[i],
+				signers,
+				peers...,
+			)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			signatures[i] = signature
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i := 2; i < coordinatorsRequired; i++ {
+		valid, err := ciphersuite.VerifySignature(signatures[i], g.publicKey, message)
+		if err != nil {
+			t.Fatalf("coordinator [%d]: [%v]", i+1, err)
+		}
+		testutils.AssertBoolsEqual(t, "signature verification result", true, valid)
+	}
+}
+
+// observerFunc adapts a function to the frost.Observer interface.
+type observerFunc func(event *frost.Event)
+
+func (of observerFunc) Observe(event *frost.Event) {
+	of(event)
+}
+
+func TestCoordinator_Run_Interrupted(t *testing.T) {
+	g := generateGroup(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	network := NewNetwork(LinkConfig{Latency: time.Millisecond})
+	defer network.Close()
+
+	coordinatorTransport, err := network.Connect("coordinator")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addresses, transports := connectSigners(t, network)
+
+	signers := g.newSigners()
+
+	// Signer 1 sends the initial commitment and never responds again. Only
+	// a threshold of signers is online so no other session can be started.
+	response, err := signers[0].Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := transports[1].Send("coordinator", response); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 1; i < threshold; i++ {
+		wg.Add(1)
+		go func(signer *Signer, transport Transport) {
+			defer wg.Done()
+			_ = signer.Run(ctx, transport, "coordinator")
+		}(signers[i], transports[uint64(i+1)])
+	}
+
+	// The signing is cancelled once all signers but signer 1 responded in
+	// the first session.
+	coordinator := g.newCoordinator()
+	received := 0
+	coordinator.SetObserver(observerFunc(func(event *frost.Event) {
+		if event.Kind == frost.EventShareReceived {
+			received++
+			if received == threshold-1 {
+				cancel()
+			}
+		}
+	}))
+
+	signature, err := coordinator.Run(ctx, coordinatorTransport, addresses)
+	if signature != nil {
+		t.Fatal("expected nil signature")
+	}
+
+	var interrupted *InterruptedError
+	if !errors.As(err, &interrupted) {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context canceled error; has [%v]", err)
+	}
+
+	testutils.AssertIntsEqual(t, "sessions started", 1, interrupted.Partial.SessionsStarted)
+	if !slices.Equal([]uint64{1}, interrupted.Partial.Unresponsive) {
+		t.Errorf("unexpected unresponsive signers: %v", interrupted.Partial.Unresponsive)
+	}
+	testutils.AssertIntsEqual(t, "number of malicious signers", 0, len(interrupted.Partial.Malicious))
+	testutils.AssertStringsEqual(
+		t,
+		"error message",
+		"signing interrupted after [1] sessions with [0] malicious and [1] "+
+			"unresponsive signers: [context canceled]",
+		err.Error(),
+	)
+
+	wg.Wait()
+}
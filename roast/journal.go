@@ -0,0 +1,355 @@
+package roast
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"threshold.network/roast/frost"
+)
+
+// Journal is an append-only log of [ROAST] state changes. The coordinator and
+// the signer append an entry to the journal before the state change becomes
+// visible to any other party so that they can restore their state after
+// a restart.
+type Journal interface {
+	// Append durably appends the entry to the journal. The entry must be
+	// persisted when the function returns with no error.
+	Append(entry *JournalEntry) error
+
+	// Entries returns all entries appended to the journal, in the order they
+	// were appended.
+	Entries() ([]*JournalEntry, error)
+}
+
+// JournalEntryKind is the kind of the journal entry.
+type JournalEntryKind string
+
+const (
+	// JournalResponse is the kind of entry appended by the coordinator for
+	// every signer response that may change the coordinator's state. The
+	// coordinator restores its state by processing all responses again.
+	JournalResponse JournalEntryKind = "response"
+
+	// JournalNonce is the kind of entry appended by the signer for every
+	// nonce generated, before the nonce commitment is published.
+	JournalNonce JournalEntryKind = "nonce"
+
+	// JournalNonceSpent is the kind of entry appended by the signer for every
+	// nonce used to produce a signature share, before the share is published.
+	JournalNonceSpent JournalEntryKind = "nonce-spent"
+)
+
+// JournalEntry is a single entry of the journal. Depending on the kind, only
+// some of the fields are set. Points are serialized with the curve's
+// SerializePoint and scalars with frost.SerializeScalar.
+type JournalEntry struct {
+	Kind JournalEntryKind `json:"kind"`
+
+	SignerIndex uint64 `json:"signerIndex,omitempty"`
+	SessionID   uint64 `json:"sessionId,omitempty"`
+
+	// SignatureShares, NextCommitments, and Authentications are set for
+	// JournalResponse, in the order of messages signed by the coordinator.
+	// Authentications are encoded the same way Blame.Authentication is.
+	SignatureShares [][]byte             `json:"signatureShares,omitempty"`
+	NextCommitments []*JournalCommitment `json:"nextCommitments,omitempty"`
+	Authentications [][]byte             `json:"authentications,omitempty"`
+
+	// Commitment is set for JournalNonce and JournalNonceSpent. HidingNonce
+	// and BindingNonce are set for JournalNonce.
+	Commitment   *JournalCommitment `json:"commitment,omitempty"`
+	HidingNonce  []byte             `json:"hidingNonce,omitempty"`
+	BindingNonce []byte             `json:"bindingNonce,omitempty"`
+}
+
+// JournalCommitment is a serialized nonce commitment of the journal entry.
+type JournalCommitment struct {
+	SignerIndex            uint64 `json:"signerIndex,omitempty"`
+	HidingNonceCommitment  []byte `json:"hidingNonceCommitment,omitempty"`
+	BindingNonceCommitment []byte `json:"bindingNonceCommitment,omitempty"`
+}
+
+// newResponseEntry creates the journal entry for the signer response. All
+// signature shares that are set must be valid scalars and all
+// authentications must be well-formed.
+func newResponseEntry(curve frost.Curve, response *BatchResponse) *JournalEntry {
+	entry := &JournalEntry{
+		Kind:        JournalResponse,
+		SignerIndex: response.SignerIndex,
+		SessionID:   response.SessionID,
+	}
+
+	for _, share := range response.SignatureShares {
+		var encoded []byte
+		if share != nil {
+			encoded = frost.SerializeScalar(curve, share)
+		}
+		entry.SignatureShares = append(entry.SignatureShares, encoded)
+	}
+
+	for _, commitment := range response.NextCommitments {
+		entry.NextCommitments = append(
+			entry.NextCommitments,
+			newJournalCommitment(curve, commitment),
+		)
+	}
+
+	for _, authentication := range response.Authentications {
+		entry.Authentications = append(
+			entry.Authentications,
+			encodeAuthentication(curve, authentication),
+		)
+	}
+
+	return entry
+}
+
+// response restores the signer response from the journal entry. The function
+// returns an error if any of the signature shares is not a valid scalar or if
+// any of the authentications is malformed.
+func (je *JournalEntry) response(curve frost.Curve) (*BatchResponse, error) {
+	response := &BatchResponse{
+		SignerIndex: je.SignerIndex,
+		SessionID:   je.SessionID,
+	}
+
+	for j, encoded := range je.SignatureShares {
+		var share *big.Int
+		if encoded != nil {
+			var err error
+			share, err = frost.DeserializeScalar(curve, encoded)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"invalid signature share for message [%d]: [%v]",
+					j,
+					err,
+				)
+			}
+		}
+		response.SignatureShares = append(response.SignatureShares, share)
+	}
+
+	for _, commitment := range je.NextCommitments {
+		response.NextCommitments = append(
+			response.NextCommitments,
+			commitment.commitment(curve),
+		)
+	}
+
+	for j, encoded := range je.Authentications {
+		authentication, err := decodeAuthentication(curve, encoded)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invalid authentication for message [%d]: [%v]",
+				j,
+				err,
+			)
+		}
+		response.Authentications = append(response.Authentications, authentication)
+	}
+
+	return response, nil
+}
+
+// newJournalCommitment serializes the nonce commitment. The function returns
+// nil for nil commitment. Points that are not valid points on the curve are
+// left empty.
+func newJournalCommitment(
+	curve frost.Curve,
+	commitment *frost.NonceCommitment,
+) *JournalCommitment {
+	if commitment == nil {
+		return nil
+	}
+
+	return &JournalCommitment{
+		SignerIndex:            commitment.SignerIndex(),
+		HidingNonceCommitment:  serializePoint(curve, commitment.HidingNonceCommitment()),
+		BindingNonceCommitment: serializePoint(curve, commitment.BindingNonceCommitment()),
+	}
+}
+
+// commitment restores the nonce commitment. The function returns nil for nil
+// journal commitment. Points that could not be deserialized are restored as
+// invalid points so that the restored commitment is rejected exactly like the
+// original one was.
+func (jc *JournalCommitment) commitment(curve frost.Curve) *frost.NonceCommitment {
+	if jc == nil {
+		return nil
+	}
+
+	hiding := curve.DeserializePoint(jc.HidingNonceCommitment)
+	if hiding == nil {
+		hiding = &frost.Point{X: big.NewInt(0), Y: big.NewInt(0)}
+	}
+	binding := curve.DeserializePoint(jc.BindingNonceCommitment)
+	if binding == nil {
+		binding = &frost.Point{X: big.NewInt(0), Y: big.NewInt(0)}
+	}
+
+	return frost.NewNonceCommitment(jc.SignerIndex, hiding, binding)
+}
+
+// isScalar returns true if the value is a valid scalar, that is, if it is
+// non-negative and lower than the group order.
+func isScalar(curve frost.Curve, value *big.Int) bool {
+	return value.Sign() >= 0 && value.Cmp(curve.Order()) < 0
+}
+
+// MemoryJournal is an in-memory Journal implementation. It does not survive
+// a restart of the process and is meant for tests.
+type MemoryJournal struct {
+	mutex   sync.Mutex
+	entries []*JournalEntry
+}
+
+// NewMemoryJournal creates a new, empty MemoryJournal.
+func NewMemoryJournal() *MemoryJournal {
+	return &MemoryJournal{}
+}
+
+// Append appends the entry to the journal.
+func (mj *MemoryJournal) Append(entry *JournalEntry) error {
+	mj.mutex.Lock()
+	defer mj.mutex.Unlock()
+
+	mj.entries = append(mj.entries, entry)
+	return nil
+}
+
+// Entries returns all entries appended to the journal.
+func (mj *MemoryJournal) Entries() ([]*JournalEntry, error) {
+	mj.mutex.Lock()
+	defer mj.mutex.Unlock()
+
+	entries := make([]*JournalEntry, len(mj.entries))
+	copy(entries, mj.entries)
+	return entries, nil
+}
+
+// FileJournal is a file-backed Journal implementation. Entries are stored as
+// JSON, one entry per line, and the file is synced after every append.
+type FileJournal struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+// NewFileJournal opens the journal file at the given path, creating it if it
+// does not exist. The parent directory is synced once the file is opened so
+// that a newly created journal file survives a crash. New entries are
+// appended to the end of the file. If the last line of the file is
+// incomplete because the process crashed in the middle of an append, the
+// line is removed.
+func NewFileJournal(path string) (*FileJournal, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("could not open journal file: [%v]", err)
+	}
+
+	if err := syncDir(filepath.Dir(path)); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("could not sync journal directory: [%v]", err)
+	}
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("could not read journal file: [%v]", err)
+	}
+
+	if complete := bytes.LastIndexByte(content, '\n') + 1; complete != len(content) {
+		if err := file.Truncate(int64(complete)); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("could not truncate journal file: [%v]", err)
+		}
+	}
+
+	return &FileJournal{file: file}, nil
+}
+
+// syncDir syncs the directory so that files created in it survive a crash.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
+
+// Append appends the entry to the journal file and syncs the file.
+func (fj *FileJournal) Append(entry *JournalEntry) error {
+	fj.mutex.Lock()
+	defer fj.mutex.Unlock()
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("could not encode journal entry: [%v]", err)
+	}
+
+	if _, err := fj.file.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("could not write journal entry: [%v]", err)
+	}
+
+	if err := fj.file.Sync(); err != nil {
+		return fmt.Errorf("could not sync journal file: [%v]", err)
+	}
+
+	return nil
+}
+
+// Entries reads all entries from the journal file. If the process crashed in
+// the middle of an append, the last line of the file may be incomplete. Such
+// a line is ignored because the entry was never acknowledged as persisted.
+func (fj *FileJournal) Entries() ([]*JournalEntry, error) {
+	fj.mutex.Lock()
+	defer fj.mutex.Unlock()
+
+	if _, err := fj.file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("could not read journal file: [%v]", err)
+	}
+
+	var entries []*JournalEntry
+
+	reader := bufio.NewReader(fj.file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// incomplete last line, if any, is ignored
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read journal file: [%v]", err)
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		entry := &JournalEntry{}
+		if err := json.Unmarshal(line, entry); err != nil {
+			return nil, fmt.Errorf(
+				"could not decode journal entry [%d]: [%v]",
+				len(entries),
+				err,
+			)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Close closes the journal file.
+func (fj *FileJournal) Close() error {
+	return fj.file.Close()
+}
@@ -0,0 +1,519 @@
+package roast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"threshold.network/roast/frost"
+	"threshold.network/roast/internal/testutils"
+)
+
+func TestFileJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+
+	journal, err := NewFileJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := []*JournalEntry{
+		{Kind: JournalResponse, SignerIndex: 1, SessionID: 2, SignatureShares: [][]byte{{3}}},
+		{Kind: JournalNonceSpent, SignerIndex: 4, Commitment: &JournalCommitment{HidingNonceCommitment: []byte{5}}},
+	}
+	for _, entry := range entries {
+		if err := journal.Append(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := journal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a crash in the middle of an append.
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.WriteString(`{"kind":"respo`); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	journal, err = NewFileJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer journal.Close()
+
+	if err := journal.Append(&JournalEntry{Kind: JournalNonce, SignerIndex: 6}); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := journal.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "number of entries", 3, len(restored))
+	testutils.AssertStringsEqual(t, "entry kind", string(JournalResponse), string(restored[0].Kind))
+	testutils.AssertUintsEqual(t, "signer index", 1, restored[0].SignerIndex)
+	testutils.AssertUintsEqual(t, "session ID", 2, restored[0].SessionID)
+	testutils.AssertIntsEqual(t, "number of signature shares", 1, len(restored[0].SignatureShares))
+	testutils.AssertBytesEqual(t, []byte{3}, restored[0].SignatureShares[0])
+	testutils.AssertStringsEqual(t, "entry kind", string(JournalNonceSpent), string(restored[1].Kind))
+	testutils.AssertBytesEqual(t, []byte{5}, restored[1].Commitment.HidingNonceCommitment)
+	testutils.AssertStringsEqual(t, "entry kind", string(JournalNonce), string(restored[2].Kind))
+	testutils.AssertUintsEqual(t, "signer index", 6, restored[2].SignerIndex)
+}
+
+func TestCoordinator_Journal(t *testing.T) {
+	g := generateGroup(t)
+	journal := NewMemoryJournal()
+
+	coordinator, err := NewCoordinatorWithJournal(
+		ciphersuite,
+		g.publicKey,
+		threshold,
+		groupSize,
+		g.verificationShares,
+		message,
+		journal,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signers := newFrostSigners(t, g)
+	signers[3].corruptShares = true
+
+	var queue []*Response
+	for _, s := range signers {
+		queue = append(queue, s.initial(t))
+	}
+
+	// Process responses until the first session completes, leaving the
+	// coordinator with signer 4 marked as malicious and some signers in the
+	// responsive set.
+	var session *Session
+	for session == nil {
+		session, _ = coordinator.Receive(queue[0])
+		queue = queue[1:]
+	}
+	if !slices.Contains(session.Signers(), 4) {
+		t.Fatal("expected signer 4 in the first session")
+	}
+	for _, signerIndex := range session.Signers() {
+		_, _ = coordinator.Receive(signers[signerIndex-1].sign(t, session))
+	}
+
+	restored, err := NewCoordinatorWithJournal(
+		ciphersuite,
+		g.publicKey,
+		threshold,
+		groupSize,
+		g.verificationShares,
+		message,
+		journal,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal([]uint64{4}, restored.Malicious()) {
+		t.Errorf("unexpected malicious signers: %v", restored.Malicious())
+	}
+	testutils.AssertIntsEqual(
+		t,
+		"sessions started",
+		coordinator.SessionsStarted(),
+		restored.SessionsStarted(),
+	)
+	if !slices.Equal(coordinator.responsive, restored.responsive) {
+		t.Errorf(
+			"unexpected responsive set\nexpected: %v\nactual:   %v",
+			coordinator.responsive,
+			restored.responsive,
+		)
+	}
+
+	// The restored coordinator continues with the remaining responses.
+	runSessions(
+		t,
+		queue,
+		func(response *Response) *Session {
+			session, _ := restored.Receive(response)
+			return session
+		},
+		func(session *Session) []*Response {
+			return signSession(t, signers, session)
+		},
+		func() bool { return restored.Signature() != nil },
+		50,
+	)
+
+	if restored.Signature() == nil {
+		t.Fatal("expected non-nil signature")
+	}
+
+	entries, err := journal.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) <= groupSize {
+		t.Errorf("expected responses of the restored coordinator in the journal")
+	}
+}
+
+func TestJournalEntry_Response(t *testing.T) {
+	curve := ciphersuite.Curve()
+
+	authentication := &frost.Signature{
+		R: curve.EcBaseMul(big.NewInt(5)),
+		Z: big.NewInt(7),
+	}
+
+	// The zero share is a valid scalar and must survive the round trip.
+	entry := newResponseEntry(curve, &BatchResponse{
+		SignerIndex:     1,
+		SessionID:       2,
+		SignatureShares: []*big.Int{big.NewInt(0), nil},
+		Authentications: []*frost.Signature{authentication, nil},
+	})
+	testutils.AssertIntsEqual(t, "encoded share length", 32, len(entry.SignatureShares[0]))
+
+	response, err := entry.response(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertIntsEqual(t, "number of signature shares", 2, len(response.SignatureShares))
+	if response.SignatureShares[0] == nil {
+		t.Fatal("expected non-nil signature share")
+	}
+	testutils.AssertBigIntsEqual(t, "signature share", big.NewInt(0), response.SignatureShares[0])
+	if response.SignatureShares[1] != nil {
+		t.Fatal("expected nil signature share")
+	}
+
+	testutils.AssertIntsEqual(t, "number of authentications", 2, len(response.Authentications))
+	if response.Authentications[0] == nil {
+		t.Fatal("expected non-nil authentication")
+	}
+	testutils.AssertBytesEqual(
+		t,
+		curve.SerializePoint(authentication.R),
+		curve.SerializePoint(response.Authentications[0].R),
+	)
+	testutils.AssertBigIntsEqual(t, "authentication z", authentication.Z, response.Authentications[0].Z)
+	if response.Authentications[1] != nil {
+		t.Fatal("expected nil authentication")
+	}
+}
+
+func TestNewCoordinatorWithJournal_Failures(t *testing.T) {
+	g := generateGroup(t)
+	order := ciphersuite.Curve().Order()
+
+	tests := map[string]struct {
+		signatureShare []byte
+		authentication []byte
+		expectedErr    string
+	}{
+		"truncated signature share": {
+			signatureShare: []byte{3},
+			expectedErr: "could not restore journal entry [1]: [invalid signature " +
+				"share for message [0]: [scalar must be [32] bytes long; has [1]]]",
+		},
+		"signature share not lower than the group order": {
+			signatureShare: order.Bytes(),
+			expectedErr: "could not restore journal entry [1]: [invalid signature " +
+				"share for message [0]: [scalar is not lower than the group order]]",
+		},
+		"truncated authentication": {
+			signatureShare: make([]byte, 32),
+			authentication: []byte{3},
+			expectedErr: "could not restore journal entry [1]: [invalid " +
+				"authentication for message [0]: [authentication must be [97] " +
+				"bytes long; has [1]]]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			journal := NewMemoryJournal()
+			entries := []*JournalEntry{
+				{Kind: JournalNonce, SignerIndex: 1},
+				{
+					Kind:            JournalResponse,
+					SignerIndex:     1,
+					SessionID:       1,
+					SignatureShares: [][]byte{test.signatureShare},
+					Authentications: [][]byte{test.authentication},
+				},
+			}
+			for _, entry := range entries {
+				if err := journal.Append(entry); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			_, err := NewCoordinatorWithJournal(
+				ciphersuite,
+				g.publicKey,
+				threshold,
+				groupSize,
+				g.verificationShares,
+				message,
+				journal,
+			)
+			if err == nil {
+				t.Fatal("expected non-nil error")
+			}
+			testutils.AssertStringsEqual(t, "restore error", test.expectedErr, err.Error())
+		})
+	}
+}
+
+func TestSigner_Journal(t *testing.T) {
+	g := generateGroup(t)
+	journal := NewMemoryJournal()
+
+	newSigner := func() *Signer {
+		signer, err := NewSignerWithJournal(
+			ciphersuite,
+			1,
+			g.publicKey,
+			threshold,
+			groupSize,
+			g.secretKeyShares[1],
+			journal,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signer
+	}
+
+	signer := newSigner()
+	others := g.newSigners()
+
+	newSession := func(id uint64, own *Response) *Session {
+		session := &Session{ID: id, Message: message}
+		session.Commitments = append(session.Commitments, own.NextCommitment)
+		for _, other := range others[1:threshold] {
+			response, err := other.Commit()
+			if err != nil {
+				t.Fatal(err)
+			}
+			session.Commitments = append(session.Commitments, response.NextCommitment)
+		}
+		return session
+	}
+
+	first, err := signer.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := signer.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spentSession := newSession(1, first)
+	if _, err := signer.Sign(spentSession); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restart the signer with the same journal.
+	restored := newSigner()
+
+	_, err = restored.Sign(spentSession)
+	if err == nil {
+		t.Fatal("expected non-nil error")
+	}
+	testutils.AssertStringsEqual(
+		t,
+		"sign error",
+		"nonce for the commitment in session [1] has already been spent",
+		err.Error(),
+	)
+
+	if _, err := restored.Sign(newSession(2, second)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSigner_Journal_OtherSigner(t *testing.T) {
+	g := generateGroup(t)
+	journal := NewMemoryJournal()
+
+	newSigner := func(signerIndex uint64) *Signer {
+		signer, err := NewSignerWithJournal(
+			ciphersuite,
+			signerIndex,
+			g.publicKey,
+			threshold,
+			groupSize,
+			g.secretKeyShares[signerIndex],
+			journal,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signer
+	}
+
+	response, err := newSigner(1).Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The journal is shared with signer 2 that recorded a spent nonce with
+	// the same commitment. It must not affect nonces of signer 1.
+	entry := &JournalEntry{
+		Kind:        JournalNonceSpent,
+		SignerIndex: 2,
+		Commitment:  newJournalCommitment(ciphersuite.Curve(), response.NextCommitment),
+	}
+	if err := journal.Append(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	session := &Session{ID: 1, Message: message}
+	session.Commitments = append(session.Commitments, response.NextCommitment)
+	for _, other := range g.newSigners()[1:threshold] {
+		response, err := other.Commit()
+		if err != nil {
+			t.Fatal(err)
+		}
+		session.Commitments = append(session.Commitments, response.NextCommitment)
+	}
+
+	if _, err := newSigner(1).Sign(session); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRun_Restart(t *testing.T) {
+	g := generateGroup(t)
+
+	coordinatorJournal := NewMemoryJournal()
+	signerJournals := make(map[uint64]Journal, groupSize)
+	for i := uint64(1); i <= uint64(groupSize); i++ {
+		signerJournals[i] = NewMemoryJournal()
+	}
+
+	// run executes the coordinator and all signers restored from their
+	// journals until the coordinator returns. The signers serve also
+	// a coordinator that crashed and never runs, so the coordinator that runs
+	// is not served by the signer instance restored from the journal but by
+	// its fork.
+	run := func(
+		ctx context.Context,
+		network *Network,
+		observer frost.Observer,
+	) (*Coordinator, *frost.Signature, error) {
+		transport, err := network.Connect("coordinator")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := network.Connect("crashed"); err != nil {
+			t.Fatal(err)
+		}
+
+		addresses, transports := connectSigners(t, network)
+
+		ctx, cancel := context.WithCancel(ctx)
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		defer cancel()
+
+		for signerIndex, signerTransport := range transports {
+			signer, err := NewSignerWithJournal(
+				ciphersuite,
+				signerIndex,
+				g.publicKey,
+				threshold,
+				groupSize,
+				g.secretKeyShares[signerIndex],
+				signerJournals[signerIndex],
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			wg.Add(1)
+			go func(transport Transport) {
+				defer wg.Done()
+				_ = signer.Run(ctx, transport, "crashed", "coordinator")
+			}(signerTransport)
+		}
+
+		coordinator, err := NewCoordinatorWithJournal(
+			ciphersuite,
+			g.publicKey,
+			threshold,
+			groupSize,
+			g.verificationShares,
+			message,
+			coordinatorJournal,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		coordinator.SetObserver(observer)
+
+		signature, err := coordinator.Run(ctx, transport, addresses)
+		return coordinator, signature, err
+	}
+
+	// Sessions never reach the signers before the restart. Both sides are
+	// stopped once the first session was started.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	network := NewNetwork(LinkConfig{Latency: time.Millisecond})
+	defer network.Close()
+	for i := uint64(1); i <= uint64(groupSize); i++ {
+		network.SetLink("coordinator", Address(fmt.Sprintf("signer-%d", i)), LinkConfig{
+			DropRate: 1,
+		})
+	}
+
+	_, _, err := run(ctx, network, observerFunc(func(event *frost.Event) {
+		if event.Kind == EventSessionStarted {
+			cancel()
+		}
+	}))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+
+	// Both sides restart from their journals. Signers send the initial
+	// commitments again and sign the session started before the restart
+	// with nonces restored from the journal.
+	ctx, cancel = context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	network = NewNetwork(LinkConfig{Latency: time.Millisecond})
+	defer network.Close()
+
+	coordinator, signature, err := run(ctx, network, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid, err := ciphersuite.VerifySignature(signature, g.publicKey, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "signature verification result", true, valid)
+	testutils.AssertIntsEqual(t, "number of malicious signers", 0, len(coordinator.Malicious()))
+}
@@ -0,0 +1,686 @@
+package roast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"threshold.network/roast/frost"
+)
+
+// machine is the [ROAST] coordinator state machine shared by the Coordinator
+// and the BatchCoordinator. Every session started by the machine covers one
+// or more messages: signers respond with one signature share and one fresh
+// nonce commitment per message, and the signing ends once there is a valid
+// signature for every message. The Coordinator is the machine signing
+// a single message.
+//
+// A signer that provided an invalid signature share or nonce commitment for
+// any of the messages is marked as malicious and never included in any new
+// session, for any of the messages.
+type machine struct {
+	coordinator *frost.Coordinator
+
+	ciphersuite        frost.Ciphersuite
+	publicKey          *frost.Point
+	threshold          int
+	groupSize          int
+	verificationShares map[uint64]*frost.Point
+	messages           [][]byte
+
+	responsive     []uint64                            // R in [ROAST]
+	commitments    map[uint64][]*frost.NonceCommitment // pre_i in [ROAST]
+	signerSessions map[uint64]uint64                   // sid_i in [ROAST]
+	malicious      map[uint64]bool                     // M in [ROAST]
+	blames         []*Blame
+
+	sessions      map[uint64]*sessionState
+	lastSessionID uint64
+
+	// signatures holds the signature of every message, in the order of
+	// messages. The signature is nil until it is produced.
+	signatures []*frost.Signature
+
+	journal  Journal
+	observer frost.Observer
+}
+
+// sessionState holds the signature shares collected so far for the session,
+// by the signer identifier and in the order of messages.
+type sessionState struct {
+	session *BatchSession
+	shares  map[uint64][]*big.Int
+}
+
+// wire converts between the batch representation of the state machine and
+// the messages exchanged with signers and peer coordinators over the
+// transport.
+type wire interface {
+	// decodeResponse returns the signer response carried by the message,
+	// if any.
+	decodeResponse(message Message) (*BatchResponse, bool)
+	// encodeSession returns the message delivering the session to signers.
+	encodeSession(session *BatchSession) Message
+	// encodeResult returns the message delivering the signatures to signers
+	// and peer coordinators.
+	encodeResult(signatures []*frost.Signature) Message
+	// decodeResult returns the signatures carried by the result message of
+	// a peer coordinator, if any.
+	decodeResult(message Message) ([]*frost.Signature, bool)
+}
+
+func newMachine(
+	ciphersuite frost.Ciphersuite,
+	publicKey *frost.Point,
+	threshold int,
+	groupSize int,
+	verificationShares map[uint64]*frost.Point,
+	messages [][]byte,
+) machine {
+	return machine{
+		coordinator: frost.NewCoordinator(
+			ciphersuite,
+			publicKey,
+			threshold,
+			groupSize,
+			verificationShares,
+		),
+		ciphersuite:        ciphersuite,
+		publicKey:          publicKey,
+		threshold:          threshold,
+		groupSize:          groupSize,
+		verificationShares: verificationShares,
+		messages:           messages,
+		commitments:        make(map[uint64][]*frost.NonceCommitment),
+		signerSessions:     make(map[uint64]uint64),
+		malicious:          make(map[uint64]bool),
+		sessions:           make(map[uint64]*sessionState),
+		signatures:         make([]*frost.Signature, len(messages)),
+	}
+}
+
+// restore processes again all responses from the journal and sets the
+// journal so that every new response is appended to it.
+func (m *machine) restore(journal Journal) error {
+	entries, err := journal.Entries()
+	if err != nil {
+		return fmt.Errorf("could not read journal: [%v]", err)
+	}
+
+	curve := m.ciphersuite.Curve()
+	for i, entry := range entries {
+		if entry.Kind != JournalResponse {
+			continue
+		}
+
+		response, err := entry.response(curve)
+		if err != nil {
+			return fmt.Errorf("could not restore journal entry [%d]: [%v]", i, err)
+		}
+
+		// Processing the same responses in the same order always leads to
+		// the same state. Errors are expected for responses that got the
+		// signer marked as malicious.
+		_, _ = m.receive(response)
+	}
+
+	m.journal = journal
+
+	return nil
+}
+
+// receive processes the response from the signer, as described in the
+// documentation of Coordinator.Receive.
+func (m *machine) receive(response *BatchResponse) (*BatchSession, error) {
+	if m.signed() {
+		return nil, fmt.Errorf("signing already completed")
+	}
+
+	if len(m.malicious) > m.groupSize-m.threshold {
+		return nil, m.tooManyMaliciousError()
+	}
+
+	if response == nil {
+		return nil, fmt.Errorf("response is nil")
+	}
+
+	i := response.SignerIndex
+	if i == 0 || i > uint64(m.groupSize) {
+		return nil, fmt.Errorf("unknown signer [%d]", i)
+	}
+
+	if m.malicious[i] {
+		return nil, fmt.Errorf("signer [%d] is marked as malicious", i)
+	}
+
+	// A signer that restarted sends the initial commitment again, even if it
+	// was asked to sign in a session it may have never received. Such a
+	// response is rejected without changing the state; the signer should get
+	// the session again.
+	if sessionID, ok := m.restartedInSession(response); ok {
+		return nil, fmt.Errorf(
+			"signer [%d] sent the initial commitment but is asked to sign "+
+				"in session [%d]",
+			i,
+			sessionID,
+		)
+	}
+
+	// A signature share that is not a scalar can not even be encoded; such
+	// a response is malformed and rejected without changing the state.
+	curve := m.ciphersuite.Curve()
+	for _, share := range response.SignatureShares {
+		if share != nil && !isScalar(curve, share) {
+			return nil, fmt.Errorf(
+				"signature share from signer [%d] is not a valid scalar",
+				i,
+			)
+		}
+	}
+
+	// Only responses authenticated by the signer change the state so that
+	// every blame record can be verified independently.
+	session, err := m.authenticate(response)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"response from signer [%d] is not authenticated: [%v]",
+			i,
+			err,
+		)
+	}
+
+	if m.journal != nil {
+		entry := newResponseEntry(curve, response)
+		if err := m.journal.Append(entry); err != nil {
+			return nil, fmt.Errorf("could not append to journal: [%v]", err)
+		}
+	}
+
+	if len(response.NextCommitments) != len(m.messages) {
+		return nil, m.markMalicious(newProtocolViolationBlame(
+			i,
+			fmt.Errorf(
+				"signer [%d] sent [%d] nonce commitments for [%d] messages",
+				i,
+				len(response.NextCommitments),
+				len(m.messages),
+			),
+		))
+	}
+
+	for j, commitment := range response.NextCommitments {
+		if err := validateCommitment(curve, i, commitment); err != nil {
+			return nil, m.markMalicious(
+				newCommitmentBlame(curve, response, j, session, err),
+			)
+		}
+	}
+
+	sessionID, inSession := m.signerSessions[i]
+	if inSession {
+		if err := m.acceptShares(i, sessionID, response); err != nil {
+			return nil, err
+		}
+		delete(m.signerSessions, i)
+	} else {
+		// The signer is not expected to send any share. This is the initial
+		// commitment; if the signer restarted and sent it again, it replaces
+		// the commitment sent before.
+		if response.SessionID != 0 || hasShare(response.SignatureShares) {
+			return nil, m.markMalicious(newProtocolViolationBlame(
+				i,
+				fmt.Errorf("unexpected signature share from signer [%d]", i),
+			))
+		}
+	}
+
+	if m.signed() {
+		return nil, nil
+	}
+
+	m.commitments[i] = response.NextCommitments
+	if !slices.Contains(m.responsive, i) {
+		m.responsive = append(m.responsive, i)
+	}
+
+	m.notify(&frost.Event{
+		Kind:        EventCommitmentReceived,
+		SignerIndex: i,
+		SessionID:   response.SessionID,
+	})
+
+	if len(m.responsive) < m.threshold {
+		return nil, nil
+	}
+
+	return m.startSession(), nil
+}
+
+// authenticate verifies the signer's authentication of the response for
+// every message and returns the session the response is for. The session is
+// nil for the initial response.
+func (m *machine) authenticate(response *BatchResponse) (*BatchSession, error) {
+	var session *BatchSession
+	if response.SessionID != 0 {
+		state, ok := m.sessions[response.SessionID]
+		if !ok {
+			return nil, fmt.Errorf("session [%d] is unknown", response.SessionID)
+		}
+		session = state.session
+	}
+
+	if len(response.Authentications) != len(m.messages) {
+		return nil, fmt.Errorf(
+			"has [%d] authentications for [%d] messages",
+			len(response.Authentications),
+			len(m.messages),
+		)
+	}
+
+	curve := m.ciphersuite.Curve()
+	for j, authentication := range response.Authentications {
+		err := frost.VerifyAuthentication(
+			m.ciphersuite,
+			response.SignerIndex,
+			m.verificationShares[response.SignerIndex],
+			responseData(curve, response, j, session),
+			authentication,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("message [%d]: [%v]", j, err)
+		}
+	}
+
+	return session, nil
+}
+
+// restartedInSession returns the identifier of the session the signer is
+// asked to sign in if the response is the initial commitment. The signer
+// sending it restarted and may have missed the session.
+func (m *machine) restartedInSession(response *BatchResponse) (uint64, bool) {
+	if response.SessionID != 0 || hasShare(response.SignatureShares) {
+		return 0, false
+	}
+	sessionID, ok := m.signerSessions[response.SignerIndex]
+	return sessionID, ok
+}
+
+// hasShare returns true if any of the signature shares is set.
+func hasShare(shares []*big.Int) bool {
+	return slices.ContainsFunc(shares, func(share *big.Int) bool {
+		return share != nil
+	})
+}
+
+// signed returns true if the signatures for all messages were produced.
+func (m *machine) signed() bool {
+	return !slices.Contains(m.signatures, nil)
+}
+
+// Malicious returns identifiers of all signers marked as malicious so far,
+// in ascending order.
+func (m *machine) Malicious() []uint64 {
+	malicious := make([]uint64, 0, len(m.malicious))
+	for i := range m.malicious {
+		malicious = append(malicious, i)
+	}
+	slices.Sort(malicious)
+	return malicious
+}
+
+// Blames returns blame records of all offences for which signers were marked
+// as malicious so far, in the order the offences were detected. Blame records
+// of invalid signature shares refer to the single message the share was
+// produced for.
+func (m *machine) Blames() []*Blame {
+	return slices.Clone(m.blames)
+}
+
+// SessionsStarted returns the number of sessions started so far.
+func (m *machine) SessionsStarted() int {
+	return int(m.lastSessionID)
+}
+
+// acceptShares verifies the signature shares sent by the signer for every
+// message of the given session and stores them. If all shares for the
+// session were collected, the signatures are aggregated and verified. If any
+// of the shares is not valid, the signer is marked as malicious.
+func (m *machine) acceptShares(
+	signerIndex uint64,
+	sessionID uint64,
+	response *BatchResponse,
+) error {
+	if response.SessionID != sessionID {
+		return m.markMalicious(newProtocolViolationBlame(
+			signerIndex,
+			fmt.Errorf(
+				"signer [%d] responded for session [%d] but was asked to "+
+					"sign in session [%d]",
+				signerIndex,
+				response.SessionID,
+				sessionID,
+			),
+		))
+	}
+
+	if len(response.SignatureShares) != len(m.messages) {
+		return m.markMalicious(newProtocolViolationBlame(
+			signerIndex,
+			fmt.Errorf(
+				"signer [%d] sent [%d] signature shares for [%d] messages",
+				signerIndex,
+				len(response.SignatureShares),
+				len(m.messages),
+			),
+		))
+	}
+
+	state := m.sessions[sessionID]
+
+	for j, share := range response.SignatureShares {
+		session := state.session.session(j)
+
+		_, err := m.coordinator.VerifySignatureShare(
+			signerIndex,
+			share,
+			session.Commitments,
+			session.Message,
+		)
+		if err != nil {
+			cause := fmt.Errorf(
+				"invalid signature share for message [%d] of session [%d]: [%v]",
+				j,
+				sessionID,
+				err,
+			)
+			m.notify(&frost.Event{
+				Kind:        frost.EventShareRejected,
+				SignerIndex: signerIndex,
+				SessionID:   sessionID,
+				Reason:      cause,
+			})
+			if share == nil {
+				return m.markMalicious(newProtocolViolationBlame(signerIndex, cause))
+			}
+			return m.markMalicious(newSignatureShareBlame(
+				m.ciphersuite.Curve(),
+				response,
+				j,
+				state.session,
+				cause,
+			))
+		}
+	}
+
+	state.shares[signerIndex] = response.SignatureShares
+
+	m.notify(&frost.Event{
+		Kind:        frost.EventShareReceived,
+		SignerIndex: signerIndex,
+		SessionID:   sessionID,
+	})
+
+	if len(state.shares) == len(state.session.Signers()) {
+		m.completeSession(state)
+	}
+
+	return nil
+}
+
+// completeSession aggregates the signature for every message of the session
+// not signed yet and keeps the valid ones. Once a session produced a valid
+// signature for the message, the signature is kept even if the signatures for
+// other messages of the session were not valid.
+func (m *machine) completeSession(state *sessionState) {
+	delete(m.sessions, state.session.ID)
+
+	for j := range m.messages {
+		if m.signatures[j] != nil {
+			continue
+		}
+
+		session := state.session.session(j)
+
+		shares := make([]*big.Int, len(session.Commitments))
+		for k, commitment := range session.Commitments {
+			shares[k] = state.shares[commitment.SignerIndex()][j]
+		}
+
+		signature, err := m.coordinator.Aggregate(
+			session.Message,
+			session.Commitments,
+			shares,
+		)
+		if err != nil {
+			continue
+		}
+
+		// All signature shares were verified, so the signature should be
+		// valid. It is verified anyway so that a signature violating
+		// ciphersuite-specific requirements is never returned. In such a case,
+		// the message is signed again in the next session.
+		valid, _ := m.ciphersuite.VerifySignature(
+			signature,
+			m.publicKey,
+			session.Message,
+		)
+		if valid {
+			m.signatures[j] = signature
+		}
+	}
+
+	if m.signed() {
+		m.notify(&frost.Event{
+			Kind:      frost.EventSignatureProduced,
+			SessionID: state.session.ID,
+		})
+	}
+}
+
+// startSession starts a new session with all signers from the responsive set
+// and resets the responsive set.
+func (m *machine) startSession() *BatchSession {
+	signers := m.responsive
+	m.responsive = nil
+	slices.Sort(signers)
+
+	m.lastSessionID++
+	sessionID := m.lastSessionID
+
+	commitments := make([][]*frost.NonceCommitment, len(m.messages))
+	for j := range m.messages {
+		commitments[j] = make([]*frost.NonceCommitment, len(signers))
+		for k, i := range signers {
+			commitments[j][k] = m.commitments[i][j]
+		}
+	}
+	for _, i := range signers {
+		m.signerSessions[i] = sessionID
+	}
+
+	session := &BatchSession{
+		ID:          sessionID,
+		Messages:    m.messages,
+		Commitments: commitments,
+	}
+
+	m.sessions[sessionID] = &sessionState{
+		session: session,
+		shares:  make(map[uint64][]*big.Int, len(signers)),
+	}
+
+	m.notify(&frost.Event{
+		Kind:      EventSessionStarted,
+		SessionID: sessionID,
+		Signers:   slices.Clone(signers),
+	})
+
+	return session
+}
+
+// markMalicious marks the blamed signer as malicious, records the blame, and
+// returns the error explaining why. The signer is removed from the responsive
+// set.
+func (m *machine) markMalicious(blame *Blame) error {
+	signerIndex := blame.SignerIndex
+	cause := blame.Reason
+
+	m.blames = append(m.blames, blame)
+
+	m.notify(&frost.Event{
+		Kind:        EventSignerMarkedMalicious,
+		SignerIndex: signerIndex,
+		SessionID:   blame.SessionID,
+		Reason:      errors.New(cause),
+	})
+	m.malicious[signerIndex] = true
+	delete(m.signerSessions, signerIndex)
+	m.responsive = slices.DeleteFunc(m.responsive, func(i uint64) bool {
+		return i == signerIndex
+	})
+
+	if len(m.malicious) > m.groupSize-m.threshold {
+		return fmt.Errorf(
+			"signer [%d] marked as malicious: [%s]; %v",
+			signerIndex,
+			cause,
+			m.tooManyMaliciousError(),
+		)
+	}
+
+	return fmt.Errorf("signer [%d] marked as malicious: [%s]", signerIndex, cause)
+}
+
+func (m *machine) tooManyMaliciousError() error {
+	return fmt.Errorf(
+		"too many malicious signers; has [%d] for group size [%d] and "+
+			"threshold [%d]",
+		len(m.malicious),
+		m.groupSize,
+		m.threshold,
+	)
+}
+
+// run executes the state machine over the transport until the signatures for
+// all messages are produced, as described in the documentation of
+// Coordinator.Run. Messages are converted with the wire.
+func (m *machine) run(
+	ctx context.Context,
+	transport Transport,
+	signers map[uint64]Address,
+	peers []Address,
+	w wire,
+) ([]*frost.Signature, error) {
+	if m.signed() {
+		m.broadcast(transport, signers, peers, w)
+		return m.signatures, nil
+	}
+
+	for signerIndex, sessionID := range m.signerSessions {
+		_ = transport.Send(
+			signers[signerIndex],
+			w.encodeSession(m.sessions[sessionID].session),
+		)
+	}
+
+	for {
+		var envelope *Envelope
+		select {
+		case <-ctx.Done():
+			return nil, &InterruptedError{Err: ctx.Err(), Partial: m.Partial()}
+		case e, ok := <-transport.Receive():
+			if !ok {
+				return nil, fmt.Errorf(
+					"transport closed before the signing completed",
+				)
+			}
+			envelope = e
+		}
+
+		if response, ok := w.decodeResponse(envelope.Message); ok {
+			if signers[response.SignerIndex] != envelope.Sender {
+				continue
+			}
+
+			if sessionID, ok := m.restartedInSession(response); ok {
+				_ = transport.Send(
+					envelope.Sender,
+					w.encodeSession(m.sessions[sessionID].session),
+				)
+				continue
+			}
+
+			session, err := m.receive(response)
+			if err != nil && len(m.malicious) > m.groupSize-m.threshold {
+				return nil, err
+			}
+
+			if m.signed() {
+				m.broadcast(transport, signers, peers, w)
+				return m.signatures, nil
+			}
+
+			if session == nil {
+				continue
+			}
+
+			for _, signerIndex := range session.Signers() {
+				// The delivery is not guaranteed anyway. If the session could
+				// not be sent, the signer is just not responsive.
+				_ = transport.Send(signers[signerIndex], w.encodeSession(session))
+			}
+			continue
+		}
+
+		signatures, ok := w.decodeResult(envelope.Message)
+		if !ok || !slices.Contains(peers, envelope.Sender) {
+			continue
+		}
+		if m.areSignaturesValid(signatures) {
+			m.signatures = signatures
+			return m.signatures, nil
+		}
+	}
+}
+
+// areSignaturesValid returns true if there is a valid signature for every
+// message.
+func (m *machine) areSignaturesValid(signatures []*frost.Signature) bool {
+	if len(signatures) != len(m.messages) {
+		return false
+	}
+	for j, signature := range signatures {
+		if signature == nil {
+			return false
+		}
+		valid, _ := m.ciphersuite.VerifySignature(
+			signature,
+			m.publicKey,
+			m.messages[j],
+		)
+		if !valid {
+			return false
+		}
+	}
+	return true
+}
+
+// broadcast sends the signatures produced by the coordinator to all signers
+// and peer coordinators.
+func (m *machine) broadcast(
+	transport Transport,
+	signers map[uint64]Address,
+	peers []Address,
+	w wire,
+) {
+	result := w.encodeResult(m.signatures)
+
+	for _, signer := range signers {
+		_ = transport.Send(signer, result)
+	}
+	for _, peer := range peers {
+		_ = transport.Send(peer, result)
+	}
+}
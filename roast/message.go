@@ -0,0 +1,283 @@
+package roast
+
+import (
+	"math/big"
+	"slices"
+
+	"threshold.network/roast/frost"
+)
+
+// Session is a single [FROST] signing session started by the [ROAST]
+// coordinator once a threshold of responsive signers is collected. The
+// session is sent to every signer whose commitment is included on the list.
+type Session struct {
+	ID          uint64                   // sid in [ROAST]
+	Message     []byte                   // msg in [ROAST]
+	Commitments []*frost.NonceCommitment // sorted by the signer identifier
+}
+
+// Signers returns identifiers of all signers taking part in the session.
+func (s *Session) Signers() []uint64 {
+	signers := make([]uint64, len(s.Commitments))
+	for i, c := range s.Commitments {
+		signers[i] = c.SignerIndex()
+	}
+	return signers
+}
+
+// batch returns the session as the batch session for the single message.
+func (s *Session) batch() *BatchSession {
+	return &BatchSession{
+		ID:          s.ID,
+		Messages:    [][]byte{s.Message},
+		Commitments: [][]*frost.NonceCommitment{s.Commitments},
+	}
+}
+
+// Response is a message sent by a signer to the [ROAST] coordinator. The
+// response carries the signature share for the session the signer was asked
+// to sign in and the fresh nonce commitment (presignature in [ROAST]) the
+// coordinator can use to start the next session.
+//
+// The very first response sent by the signer does not contain a signature
+// share, only the nonce commitment. In such a case, SessionID is zero and
+// SignatureShare is nil.
+//
+// Authentication is the signer's signature over the response and the session
+// it responds to, produced with the secret key share. It binds the response
+// to the signer so that blame records built from it can be verified by
+// anyone knowing the signer's verification share.
+type Response struct {
+	SignerIndex    uint64
+	SessionID      uint64
+	SignatureShare *big.Int
+	NextCommitment *frost.NonceCommitment
+	Authentication *frost.Signature
+}
+
+// batch returns the response as the batch response for the single message
+// signed by the coordinator.
+func (r *Response) batch() *BatchResponse {
+	return &BatchResponse{
+		SignerIndex:     r.SignerIndex,
+		SessionID:       r.SessionID,
+		SignatureShares: []*big.Int{r.SignatureShare},
+		NextCommitments: []*frost.NonceCommitment{r.NextCommitment},
+		Authentications: []*frost.Signature{r.Authentication},
+	}
+}
+
+// Result is a message sent by the [ROAST] coordinator once it produced
+// a valid signature. The result is sent to all signers and, in the
+// semi-interactive mode, to all other coordinators to end the signing.
+type Result struct {
+	Message   []byte
+	Signature *frost.Signature
+}
+
+// BatchSession is a single signing session of the batch mode of [ROAST],
+// covering several messages at once, for example, all inputs of a Taproot
+// transaction. For every message, the session holds a separate list of
+// commitments of the same signers.
+type BatchSession struct {
+	ID       uint64
+	Messages [][]byte
+	// Commitments[j] is the commitment list for Messages[j], sorted by the
+	// signer identifier.
+	Commitments [][]*frost.NonceCommitment
+}
+
+// Signers returns identifiers of all signers taking part in the session.
+func (bs *BatchSession) Signers() []uint64 {
+	if len(bs.Commitments) == 0 {
+		return nil
+	}
+	return (&Session{Commitments: bs.Commitments[0]}).Signers()
+}
+
+// session returns the [FROST] signing session for the j-th message of the
+// batch.
+func (bs *BatchSession) session(j int) *Session {
+	return &Session{
+		ID:          bs.ID,
+		Message:     bs.Messages[j],
+		Commitments: bs.Commitments[j],
+	}
+}
+
+// BatchResponse is a message sent by a signer to the coordinator in the batch
+// mode of [ROAST]. The response carries one signature share and one fresh
+// nonce commitment per message of the batch, in the order of messages.
+//
+// The very first response sent by the signer does not contain signature
+// shares, only the nonce commitments. In such a case, SessionID is zero and
+// SignatureShares is empty.
+//
+// Authentications holds the signer's authentication of the response for
+// every message, as Response.Authentication does for the single message.
+type BatchResponse struct {
+	SignerIndex     uint64
+	SessionID       uint64
+	SignatureShares []*big.Int
+	NextCommitments []*frost.NonceCommitment
+	Authentications []*frost.Signature
+}
+
+// BatchResult is a message sent by the coordinator in the batch mode of
+// [ROAST] once it produced valid signatures for all messages of the batch.
+type BatchResult struct {
+	Messages   [][]byte
+	Signatures []*frost.Signature
+}
+
+// clone returns a deep copy of the session.
+func (s *Session) clone() *Session {
+	if s == nil {
+		return nil
+	}
+	return &Session{
+		ID:          s.ID,
+		Message:     slices.Clone(s.Message),
+		Commitments: cloneCommitments(s.Commitments),
+	}
+}
+
+// clone returns a deep copy of the response.
+func (r *Response) clone() *Response {
+	if r == nil {
+		return nil
+	}
+	return &Response{
+		SignerIndex:    r.SignerIndex,
+		SessionID:      r.SessionID,
+		SignatureShare: cloneScalar(r.SignatureShare),
+		NextCommitment: cloneCommitment(r.NextCommitment),
+		Authentication: cloneSignature(r.Authentication),
+	}
+}
+
+// clone returns a deep copy of the result.
+func (r *Result) clone() *Result {
+	if r == nil {
+		return nil
+	}
+	return &Result{
+		Message:   slices.Clone(r.Message),
+		Signature: cloneSignature(r.Signature),
+	}
+}
+
+// clone returns a deep copy of the batch session.
+func (bs *BatchSession) clone() *BatchSession {
+	if bs == nil {
+		return nil
+	}
+	session := &BatchSession{
+		ID:       bs.ID,
+		Messages: cloneMessages(bs.Messages),
+	}
+	if bs.Commitments != nil {
+		session.Commitments = make([][]*frost.NonceCommitment, len(bs.Commitments))
+		for j, commitments := range bs.Commitments {
+			session.Commitments[j] = cloneCommitments(commitments)
+		}
+	}
+	return session
+}
+
+// clone returns a deep copy of the batch response.
+func (br *BatchResponse) clone() *BatchResponse {
+	if br == nil {
+		return nil
+	}
+	response := &BatchResponse{
+		SignerIndex:     br.SignerIndex,
+		SessionID:       br.SessionID,
+		NextCommitments: cloneCommitments(br.NextCommitments),
+	}
+	if br.SignatureShares != nil {
+		response.SignatureShares = make([]*big.Int, len(br.SignatureShares))
+		for j, share := range br.SignatureShares {
+			response.SignatureShares[j] = cloneScalar(share)
+		}
+	}
+	if br.Authentications != nil {
+		response.Authentications = make([]*frost.Signature, len(br.Authentications))
+		for j, authentication := range br.Authentications {
+			response.Authentications[j] = cloneSignature(authentication)
+		}
+	}
+	return response
+}
+
+// clone returns a deep copy of the batch result.
+func (br *BatchResult) clone() *BatchResult {
+	if br == nil {
+		return nil
+	}
+	result := &BatchResult{Messages: cloneMessages(br.Messages)}
+	if br.Signatures != nil {
+		result.Signatures = make([]*frost.Signature, len(br.Signatures))
+		for j, signature := range br.Signatures {
+			result.Signatures[j] = cloneSignature(signature)
+		}
+	}
+	return result
+}
+
+func cloneMessages(messages [][]byte) [][]byte {
+	if messages == nil {
+		return nil
+	}
+	cloned := make([][]byte, len(messages))
+	for j, message := range messages {
+		cloned[j] = slices.Clone(message)
+	}
+	return cloned
+}
+
+func cloneCommitments(commitments []*frost.NonceCommitment) []*frost.NonceCommitment {
+	if commitments == nil {
+		return nil
+	}
+	cloned := make([]*frost.NonceCommitment, len(commitments))
+	for i, commitment := range commitments {
+		cloned[i] = cloneCommitment(commitment)
+	}
+	return cloned
+}
+
+func cloneCommitment(commitment *frost.NonceCommitment) *frost.NonceCommitment {
+	if commitment == nil {
+		return nil
+	}
+	return frost.NewNonceCommitment(
+		commitment.SignerIndex(),
+		clonePoint(commitment.HidingNonceCommitment()),
+		clonePoint(commitment.BindingNonceCommitment()),
+	)
+}
+
+func cloneSignature(signature *frost.Signature) *frost.Signature {
+	if signature == nil {
+		return nil
+	}
+	return &frost.Signature{
+		R: clonePoint(signature.R),
+		Z: cloneScalar(signature.Z),
+	}
+}
+
+func clonePoint(point *frost.Point) *frost.Point {
+	if point == nil {
+		return nil
+	}
+	return &frost.Point{X: cloneScalar(point.X), Y: cloneScalar(point.Y)}
+}
+
+func cloneScalar(value *big.Int) *big.Int {
+	if value == nil {
+		return nil
+	}
+	return new(big.Int).Set(value)
+}
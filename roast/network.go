@@ -0,0 +1,360 @@
+package roast
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// LinkConfig configures the behaviour of a one-directional link between two
+// parties of the simulated network.
+type LinkConfig struct {
+	Latency  time.Duration // base delay of every message sent over the link
+	Jitter   time.Duration // maximum random delay added to the latency
+	DropRate float64       // probability of dropping a message, from 0 to 1
+	Reorder  bool          // if true, messages may be delivered out of order
+}
+
+// Network is an in-memory simulated network connecting [ROAST] parties
+// running in the same process. Messages are delivered asynchronously by
+// goroutines, with the latency, jitter, drops, and reordering configured per
+// link. The network is meant to test the asynchronous behaviour of [ROAST]
+// without real networking.
+type Network struct {
+	mutex sync.Mutex
+
+	defaultLink LinkConfig
+	links       map[linkID]*link
+	endpoints   map[Address]*endpoint
+	random      *rand.Rand
+
+	done   chan struct{}
+	closed bool
+}
+
+// linkID identifies a one-directional link between two parties.
+type linkID struct {
+	sender   Address
+	receiver Address
+}
+
+// link is a one-directional link between two parties. If the link does not
+// allow reordering, all messages are delivered by a single goroutine reading
+// from the queue, one after another.
+type link struct {
+	config LinkConfig
+	queue  *queue[*delivery]
+}
+
+// delivery is a message scheduled to be delivered at the given time.
+type delivery struct {
+	envelope  *Envelope
+	deliverAt time.Time
+}
+
+// NewNetwork creates a new simulated network. The provided link configuration
+// is used for all links not configured explicitly with SetLink.
+func NewNetwork(defaultLink LinkConfig) *Network {
+	return &Network{
+		defaultLink: defaultLink,
+		links:       make(map[linkID]*link),
+		endpoints:   make(map[Address]*endpoint),
+		random:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		done:        make(chan struct{}),
+	}
+}
+
+// SetLink configures the link from the sender to the receiver. The
+// configuration applies to messages sent after this function returns.
+func (n *Network) SetLink(sender, receiver Address, config LinkConfig) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	id := linkID{sender, receiver}
+	if l, ok := n.links[id]; ok {
+		l.config = config
+		return
+	}
+
+	n.links[id] = n.newLink(config)
+}
+
+// Connect connects a new party with the given address to the network and
+// returns the transport the party should use. The function returns an error
+// if the address is already taken or the network is closed.
+func (n *Network) Connect(address Address) (Transport, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.closed {
+		return nil, fmt.Errorf("network is closed")
+	}
+
+	if _, ok := n.endpoints[address]; ok {
+		return nil, fmt.Errorf("address [%s] is already connected", address)
+	}
+
+	e := &endpoint{
+		network: n,
+		address: address,
+		inbox:   newQueue[*Envelope](),
+		out:     make(chan *Envelope),
+		done:    make(chan struct{}),
+	}
+	n.endpoints[address] = e
+
+	go e.pump()
+
+	return e, nil
+}
+
+// Close closes the network and all transports connected to it.
+func (n *Network) Close() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.closed {
+		return
+	}
+	n.closed = true
+
+	for _, e := range n.endpoints {
+		e.close()
+	}
+	close(n.done)
+}
+
+// send schedules the delivery of the message over the link from the sender
+// to the receiver.
+func (n *Network) send(envelope *Envelope) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.closed {
+		return fmt.Errorf("network is closed")
+	}
+
+	if _, ok := n.endpoints[envelope.Receiver]; !ok {
+		return fmt.Errorf("unknown receiver [%s]", envelope.Receiver)
+	}
+
+	id := linkID{envelope.Sender, envelope.Receiver}
+	l, ok := n.links[id]
+	if !ok {
+		l = n.newLink(n.defaultLink)
+		n.links[id] = l
+	}
+
+	if l.config.DropRate > 0 && n.random.Float64() < l.config.DropRate {
+		return nil
+	}
+
+	delay := l.config.Latency
+	if l.config.Jitter > 0 {
+		delay += time.Duration(n.random.Int63n(int64(l.config.Jitter) + 1))
+	}
+
+	if l.config.Reorder {
+		time.AfterFunc(delay, func() { n.deliver(envelope) })
+		return nil
+	}
+
+	l.queue.push(&delivery{envelope, time.Now().Add(delay)})
+
+	return nil
+}
+
+// newLink creates a new link and starts the goroutine delivering messages in
+// order. Must be called with the mutex held.
+func (n *Network) newLink(config LinkConfig) *link {
+	l := &link{
+		config: config,
+		queue:  newQueue[*delivery](),
+	}
+
+	go func() {
+		for {
+			d, ok := l.queue.pop(n.done)
+			if !ok {
+				return
+			}
+
+			timer := time.NewTimer(time.Until(d.deliverAt))
+			select {
+			case <-timer.C:
+				n.deliver(d.envelope)
+			case <-n.done:
+				timer.Stop()
+				return
+			}
+		}
+	}()
+
+	return l
+}
+
+// disconnect closes the endpoint and removes it from the network so that the
+// address can be connected again and messages sent to the address are
+// rejected until then.
+func (n *Network) disconnect(e *endpoint) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.endpoints[e.address] == e {
+		delete(n.endpoints, e.address)
+	}
+	e.close()
+}
+
+// deliver puts the message into the receiver's inbox. Messages for closed
+// endpoints are dropped.
+func (n *Network) deliver(envelope *Envelope) {
+	n.mutex.Lock()
+	e, ok := n.endpoints[envelope.Receiver]
+	n.mutex.Unlock()
+
+	if !ok {
+		return
+	}
+
+	select {
+	case <-e.done:
+	default:
+		e.inbox.push(envelope)
+	}
+}
+
+// endpoint is the Transport implementation for a party connected to the
+// simulated network.
+type endpoint struct {
+	network *Network
+	address Address
+
+	inbox *queue[*Envelope]
+	out   chan *Envelope
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+func (e *endpoint) Address() Address {
+	return e.address
+}
+
+// Send sends a copy of the message so that neither the sender nor the
+// receiver can modify the message the other one holds. Messages of types not
+// defined by this package are sent as they are.
+func (e *endpoint) Send(receiver Address, message Message) error {
+	select {
+	case <-e.done:
+		return fmt.Errorf("transport is closed")
+	default:
+	}
+
+	return e.network.send(&Envelope{
+		Sender:   e.address,
+		Receiver: receiver,
+		Message:  copyMessage(message),
+	})
+}
+
+func (e *endpoint) Receive() <-chan *Envelope {
+	return e.out
+}
+
+// Close closes the transport and disconnects it from the network. Once the
+// transport is closed, the address can be connected again.
+func (e *endpoint) Close() error {
+	e.network.disconnect(e)
+	return nil
+}
+
+func (e *endpoint) close() {
+	e.closeOnce.Do(func() { close(e.done) })
+}
+
+// copyMessage returns a deep copy of the message if the message is one of the
+// types defined by this package and the message itself otherwise.
+func copyMessage(message Message) Message {
+	switch m := message.(type) {
+	case *Session:
+		return m.clone()
+	case *Response:
+		return m.clone()
+	case *Result:
+		return m.clone()
+	case *BatchSession:
+		return m.clone()
+	case *BatchResponse:
+		return m.clone()
+	case *BatchResult:
+		return m.clone()
+	default:
+		return message
+	}
+}
+
+// pump forwards messages from the unbounded inbox to the receive channel
+// until the endpoint is closed.
+func (e *endpoint) pump() {
+	defer close(e.out)
+
+	for {
+		envelope, ok := e.inbox.pop(e.done)
+		if !ok {
+			return
+		}
+
+		select {
+		case e.out <- envelope:
+		case <-e.done:
+			return
+		}
+	}
+}
+
+// queue is an unbounded FIFO queue. Pushing to the queue never blocks so the
+// sender is never stalled by a slow receiver.
+type queue[T any] struct {
+	mutex  sync.Mutex
+	items  []T
+	signal chan struct{}
+}
+
+func newQueue[T any]() *queue[T] {
+	return &queue[T]{signal: make(chan struct{}, 1)}
+}
+
+func (q *queue[T]) push(item T) {
+	q.mutex.Lock()
+	q.items = append(q.items, item)
+	q.mutex.Unlock()
+
+	select {
+	case q.signal <- struct{}{}:
+	default:
+	}
+}
+
+// pop returns the first item from the queue, waiting for it if the queue is
+// empty. The function returns false if the done channel was closed.
+func (q *queue[T]) pop(done <-chan struct{}) (T, bool) {
+	for {
+		q.mutex.Lock()
+		if len(q.items) > 0 {
+			item := q.items[0]
+			q.items = q.items[1:]
+			q.mutex.Unlock()
+			return item, true
+		}
+		q.mutex.Unlock()
+
+		select {
+		case <-q.signal:
+		case <-done:
+			var zero T
+			return zero, false
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package roast
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"threshold.network/roast/frost"
+	"threshold.network/roast/internal/testutils"
+)
+
+func TestNetwork_Delivery(t *testing.T) {
+	network := NewNetwork(LinkConfig{Latency: 5 * time.Millisecond})
+	defer network.Close()
+
+	alice, err := network.Connect("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := network.Connect("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if err := alice.Send("bob", &Session{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	envelope := <-bob.Receive()
+	if elapsed := time.Since(start); elapsed < 5*time.Millisecond {
+		t.Errorf("message delivered too early, after [%v]", elapsed)
+	}
+
+	testutils.AssertStringsEqual(t, "sender", "alice", string(envelope.Sender))
+	testutils.AssertStringsEqual(t, "receiver", "bob", string(envelope.Receiver))
+	testutils.AssertStringsEqual(t, "message type", "roast/session", envelope.Message.Type())
+}
+
+func TestNetwork_Order(t *testing.T) {
+	tests := map[string]struct {
+		reorder         bool
+		expectReordered bool
+	}{
+		"in order": {
+			reorder:         false,
+			expectReordered: false,
+		},
+		"reordered": {
+			reorder:         true,
+			expectReordered: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			network := NewNetwork(LinkConfig{
+				Jitter:  10 * time.Millisecond,
+				Reorder: test.reorder,
+			})
+			defer network.Close()
+
+			alice, err := network.Connect("alice")
+			if err != nil {
+				t.Fatal(err)
+			}
+			bob, err := network.Connect("bob")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			messages := 100
+			for i := 1; i <= messages; i++ {
+				if err := alice.Send("bob", &Session{ID: uint64(i)}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			reordered := false
+			for i := 1; i <= messages; i++ {
+				envelope := <-bob.Receive()
+				if envelope.Message.(*Session).ID != uint64(i) {
+					reordered = true
+				}
+			}
+
+			testutils.AssertBoolsEqual(
+				t,
+				"messages reordered",
+				test.expectReordered,
+				reordered,
+			)
+		})
+	}
+}
+
+func TestNetwork_Drop(t *testing.T) {
+	network := NewNetwork(LinkConfig{})
+	defer network.Close()
+
+	alice, err := network.Connect("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := network.Connect("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	network.SetLink("alice", "bob", LinkConfig{DropRate: 1})
+
+	if err := alice.Send("bob", &Session{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := bob.Send("alice", &Session{ID: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	envelope := <-alice.Receive()
+	testutils.AssertUintsEqual(t, "session ID", 2, envelope.Message.(*Session).ID)
+
+	select {
+	case envelope := <-bob.Receive():
+		t.Fatalf("unexpected message delivered: [%v]", envelope)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestNetwork_Errors(t *testing.T) {
+	network := NewNetwork(LinkConfig{})
+
+	alice, err := network.Connect("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = network.Connect("alice")
+	testutils.AssertStringsEqual(
+		t,
+		"connect error",
+		"address [alice] is already connected",
+		err.Error(),
+	)
+
+	err = alice.Send("bob", &Session{})
+	testutils.AssertStringsEqual(t, "send error", "unknown receiver [bob]", err.Error())
+
+	network.Close()
+
+	if _, ok := <-alice.Receive(); ok {
+		t.Error("expected receive channel to be closed")
+	}
+
+	err = alice.Send("alice", &Session{})
+	testutils.AssertStringsEqual(t, "send error", "transport is closed", err.Error())
+}
+
+func TestNetwork_Copy(t *testing.T) {
+	network := NewNetwork(LinkConfig{})
+	defer network.Close()
+
+	alice, err := network.Connect("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := network.Connect("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := &Response{
+		SignerIndex:    1,
+		SessionID:      2,
+		SignatureShare: big.NewInt(3),
+		NextCommitment: frost.NewNonceCommitment(
+			1,
+			&frost.Point{X: big.NewInt(4), Y: big.NewInt(5)},
+			&frost.Point{X: big.NewInt(6), Y: big.NewInt(7)},
+		),
+	}
+	if err := alice.Send("bob", response); err != nil {
+		t.Fatal(err)
+	}
+
+	response.SignatureShare.SetInt64(8)
+	response.NextCommitment.HidingNonceCommitment().X.SetInt64(9)
+
+	received := (<-bob.Receive()).Message.(*Response)
+	if received == response {
+		t.Fatal("expected the receiver to get a copy of the message")
+	}
+	testutils.AssertBigIntsEqual(
+		t,
+		"signature share",
+		big.NewInt(3),
+		received.SignatureShare,
+	)
+	testutils.AssertBigIntsEqual(
+		t,
+		"hiding nonce commitment",
+		big.NewInt(4),
+		received.NextCommitment.HidingNonceCommitment().X,
+	)
+}
+
+func TestNetwork_Reconnect(t *testing.T) {
+	network := NewNetwork(LinkConfig{})
+	defer network.Close()
+
+	alice, err := network.Connect("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := network.Connect("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := bob.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	err = alice.Send("bob", &Session{})
+	testutils.AssertStringsEqual(t, "send error", "unknown receiver [bob]", err.Error())
+
+	bob, err = network.Connect("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := alice.Send("bob", &Session{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	envelope := <-bob.Receive()
+	testutils.AssertUintsEqual(t, "session", 1, envelope.Message.(*Session).ID)
+}
+
+func TestNetwork_Roast(t *testing.T) {
+	g := generateGroup(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	network := NewNetwork(LinkConfig{
+		Latency: time.Millisecond,
+		Jitter:  5 * time.Millisecond,
+		Reorder: true,
+	})
+	defer network.Close()
+
+	// The coordinator never hears back from signers 3 and 8.
+	network.SetLink("signer-3", "coordinator", LinkConfig{DropRate: 1})
+	network.SetLink("signer-8", "coordinator", LinkConfig{DropRate: 1})
+
+	coordinatorTransport, err := network.Connect("coordinator")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addresses := make(map[uint64]Address, groupSize)
+	transports := make(map[uint64]Transport, groupSize)
+	for i := uint64(1); i <= uint64(groupSize); i++ {
+		addresses[i] = Address(fmt.Sprintf("signer-%d", i))
+		transports[i], err = network.Connect(addresses[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i, signer := range g.newSigners() {
+		wg.Add(1)
+		go func(signer *Signer, transport Transport) {
+			defer wg.Done()
+			if err := signer.Run(ctx, transport, "coordinator"); err != nil {
+				t.Error(err)
+			}
+		}(signer, transports[uint64(i+1)])
+	}
+
+	signature, err := g.newCoordinator().Run(ctx, coordinatorTransport, addresses)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	network.Close()
+	wg.Wait()
+
+	valid, err := ciphersuite.VerifySignature(signature, g.publicKey, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "signature verification result", true, valid)
+}
@@ -0,0 +1,44 @@
+package roast
+
+import (
+	"time"
+
+	"threshold.network/roast/frost"
+)
+
+// Kinds of events reported by the [ROAST] coordinator to the observer, in
+// addition to frost.EventShareReceived, frost.EventShareRejected, and
+// frost.EventSignatureProduced. All events reported by the coordinator have
+// the session identifier set, if applicable.
+const (
+	// EventSessionStarted is reported once the coordinator started a new
+	// session. The signers included in the session are set on the event.
+	EventSessionStarted frost.EventKind = "roast/session-started"
+
+	// EventCommitmentReceived is reported once the coordinator accepted
+	// a nonce commitment from the signer. The session identifier is zero for
+	// the initial commitment and is the session the signer responded for
+	// otherwise.
+	EventCommitmentReceived frost.EventKind = "roast/commitment-received"
+
+	// EventSignerMarkedMalicious is reported once the coordinator marked the
+	// signer as malicious. The reason is set on the event.
+	EventSignerMarkedMalicious frost.EventKind = "roast/signer-marked-malicious"
+)
+
+// SetObserver sets the observer notified about the events of the
+// coordinator. Passing nil removes the observer. Responses restored from the
+// journal are not reported.
+func (m *machine) SetObserver(observer frost.Observer) {
+	m.observer = observer
+}
+
+// notify sets the event time to the current time and reports the event to
+// the observer, if set.
+func (m *machine) notify(event *frost.Event) {
+	if m.observer == nil {
+		return
+	}
+	event.Time = time.Now()
+	m.observer.Observe(event)
+}
@@ -0,0 +1,106 @@
+package roast
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"threshold.network/roast/frost"
+	"threshold.network/roast/internal/testutils"
+)
+
+type recordingObserver struct {
+	mutex  sync.Mutex
+	events []*frost.Event
+}
+
+func (ro *recordingObserver) Observe(event *frost.Event) {
+	ro.mutex.Lock()
+	defer ro.mutex.Unlock()
+	ro.events = append(ro.events, event)
+}
+
+func (ro *recordingObserver) filter(kind frost.EventKind) []*frost.Event {
+	ro.mutex.Lock()
+	defer ro.mutex.Unlock()
+
+	var events []*frost.Event
+	for _, event := range ro.events {
+		if event.Kind == kind {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func TestCoordinator_Observer(t *testing.T) {
+	g := generateGroup(t)
+	coordinator := g.newCoordinator()
+
+	observer := &recordingObserver{}
+	coordinator.SetObserver(observer)
+
+	signers := newFrostSigners(t, g)
+	signers[1].corruptShares = true
+
+	runCoordinator(t, coordinator, signers, 50)
+
+	if coordinator.Signature() == nil {
+		t.Fatal("expected non-nil signature")
+	}
+
+	started := observer.filter(EventSessionStarted)
+	testutils.AssertIntsEqual(
+		t,
+		"number of session started events",
+		coordinator.SessionsStarted(),
+		len(started),
+	)
+	for _, event := range started {
+		testutils.AssertIntsEqual(t, "number of session signers", threshold, len(event.Signers))
+	}
+
+	// The commitment of the malicious signer is accepted only with its
+	// initial response, before the invalid share is sent.
+	commitments := observer.filter(EventCommitmentReceived)
+	for _, event := range commitments {
+		if event.SessionID != 0 && event.SignerIndex == 2 {
+			t.Errorf("unexpected commitment from malicious signer")
+		}
+	}
+
+	rejected := observer.filter(frost.EventShareRejected)
+	testutils.AssertIntsEqual(t, "number of share rejected events", 1, len(rejected))
+	testutils.AssertUintsEqual(t, "rejected signer", 2, rejected[0].SignerIndex)
+	if rejected[0].Reason == nil {
+		t.Error("expected non-nil rejection reason")
+	}
+
+	malicious := observer.filter(EventSignerMarkedMalicious)
+	testutils.AssertIntsEqual(t, "number of signer marked malicious events", 1, len(malicious))
+	testutils.AssertUintsEqual(t, "malicious signer", 2, malicious[0].SignerIndex)
+	testutils.AssertUintsEqual(
+		t,
+		"malicious signer session",
+		rejected[0].SessionID,
+		malicious[0].SessionID,
+	)
+
+	// Every accepted share but the last one, completing the signature, is
+	// followed by an accepted commitment. Initial commitments are not.
+	received := observer.filter(frost.EventShareReceived)
+	testutils.AssertIntsEqual(
+		t,
+		"number of share received events",
+		len(commitments)-groupSize+1,
+		len(received),
+	)
+
+	produced := observer.filter(frost.EventSignatureProduced)
+	testutils.AssertIntsEqual(t, "number of signature produced events", 1, len(produced))
+	if !slices.ContainsFunc(started, func(event *frost.Event) bool {
+		return event.SessionID == produced[0].SessionID
+	}) {
+		t.Errorf("signature produced in unknown session [%d]", produced[0].SessionID)
+	}
+}
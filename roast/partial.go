@@ -0,0 +1,66 @@
+package roast
+
+import (
+	"fmt"
+	"slices"
+)
+
+// PartialResult is the progress of the [ROAST] signing stopped before the
+// signature was produced, for example, because the context deadline was
+// exceeded.
+type PartialResult struct {
+	// SessionsStarted is the number of sessions started so far.
+	SessionsStarted int
+	// Malicious are identifiers of signers marked as malicious, in ascending
+	// order. Blames holds the evidence.
+	Malicious []uint64
+	Blames    []*Blame
+	// Unresponsive are identifiers of signers asked to sign in a session
+	// that have not responded yet, in ascending order. Unresponsive signers
+	// are suspects: they may be malicious or just slow.
+	Unresponsive []uint64
+}
+
+// InterruptedError is returned when the [ROAST] signing was stopped by the
+// context before the signature was produced. The error unwraps to the context
+// error and carries the partial result of the signing.
+type InterruptedError struct {
+	Err     error
+	Partial *PartialResult
+}
+
+func (ie *InterruptedError) Error() string {
+	return fmt.Sprintf(
+		"signing interrupted after [%d] sessions with [%d] malicious and "+
+			"[%d] unresponsive signers: [%v]",
+		ie.Partial.SessionsStarted,
+		len(ie.Partial.Malicious),
+		len(ie.Partial.Unresponsive),
+		ie.Err,
+	)
+}
+
+func (ie *InterruptedError) Unwrap() error {
+	return ie.Err
+}
+
+// Partial returns the progress of the signing so far.
+func (m *machine) Partial() *PartialResult {
+	return &PartialResult{
+		SessionsStarted: m.SessionsStarted(),
+		Malicious:       m.Malicious(),
+		Blames:          m.Blames(),
+		Unresponsive:    unresponsive(m.signerSessions),
+	}
+}
+
+// unresponsive returns identifiers of signers asked to sign in a session that
+// have not responded yet, in ascending order.
+func unresponsive(signerSessions map[uint64]uint64) []uint64 {
+	signers := make([]uint64, 0, len(signerSessions))
+	for i := range signerSessions {
+		signers = append(signers, i)
+	}
+	slices.Sort(signers)
+	return signers
+}
@@ -0,0 +1,137 @@
+package roast
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"threshold.network/roast/frost"
+	"threshold.network/roast/internal/testutils"
+)
+
+var ciphersuite = frost.NewBip340Ciphersuite()
+var threshold = 6
+var groupSize = 10
+
+var message = []byte("All we have to decide is what to do with the time that is given us")
+
+// group holds the key material of a group generated for the test.
+type group struct {
+	publicKey          *frost.Point
+	secretKeyShares    map[uint64]*big.Int
+	verificationShares map[uint64]*frost.Point
+}
+
+func generateGroup(t *testing.T) *group {
+	curve := ciphersuite.Curve()
+	order := curve.Order()
+
+	secretKey, err := rand.Int(rand.Reader, order)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The public key may have an odd Y coordinate. Signers negate their
+	// secret key shares in such a case, as required by [BIP-340].
+	publicKey := curve.EcBaseMul(secretKey)
+
+	keyShares := testutils.GenerateKeyShares(
+		secretKey,
+		groupSize,
+		threshold,
+		order,
+	)
+
+	g := &group{
+		publicKey:          publicKey,
+		secretKeyShares:    make(map[uint64]*big.Int, groupSize),
+		verificationShares: make(map[uint64]*frost.Point, groupSize),
+	}
+	for i, share := range keyShares {
+		signerIndex := uint64(i + 1)
+		g.secretKeyShares[signerIndex] = share
+		g.verificationShares[signerIndex] = curve.EcBaseMul(share)
+	}
+
+	return g
+}
+
+func (g *group) newCoordinator() *Coordinator {
+	return NewCoordinator(
+		ciphersuite,
+		g.publicKey,
+		threshold,
+		groupSize,
+		g.verificationShares,
+		message,
+	)
+}
+
+func (g *group) newSigners() []*Signer {
+	signers := make([]*Signer, groupSize)
+	for i := range signers {
+		signerIndex := uint64(i + 1)
+		signers[i] = NewSigner(
+			ciphersuite,
+			signerIndex,
+			g.publicKey,
+			threshold,
+			groupSize,
+			g.secretKeyShares[signerIndex],
+		)
+	}
+	return signers
+}
+
+// runSessions delivers all queued responses to the coordinator with receive
+// and all sessions started to the signers with sign until done returns true
+// or there are no more responses. The test fails if more than maxSessions
+// sessions were started. All sessions started are returned.
+func runSessions[R any, S any](
+	t *testing.T,
+	queue []*R,
+	receive func(response *R) *S,
+	sign func(session *S) []*R,
+	done func() bool,
+	maxSessions int,
+) []*S {
+	var sessions []*S
+	for len(queue) > 0 && !done() {
+		response := queue[0]
+		queue = queue[1:]
+
+		session := receive(response)
+		if session == nil {
+			continue
+		}
+		sessions = append(sessions, session)
+
+		if len(sessions) > maxSessions {
+			t.Fatalf("signing not completed in [%d] sessions", maxSessions)
+		}
+
+		queue = append(queue, sign(session)...)
+	}
+	return sessions
+}
+
+// connectSigners connects all signers of the group to the network. Addresses
+// and transports are indexed by the signer identifier.
+func connectSigners(
+	t *testing.T,
+	network *Network,
+) (map[uint64]Address, map[uint64]Transport) {
+	addresses := make(map[uint64]Address, groupSize)
+	transports := make(map[uint64]Transport, groupSize)
+	for i := uint64(1); i <= uint64(groupSize); i++ {
+		addresses[i] = Address(fmt.Sprintf("signer-%d", i))
+
+		var err error
+		transports[i], err = network.Connect(addresses[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return addresses, transports
+}
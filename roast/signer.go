@@ -0,0 +1,578 @@
+package roast
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"threshold.network/roast/frost"
+)
+
+// Signer represents a single signer of the [ROAST] protocol. The signer wraps
+// [FROST] signer and attaches a fresh nonce commitment to every signature
+// share it produces so that the coordinator can start the next session
+// without an additional round trip.
+//
+// The signer keeps track of nonces it generated and refuses to use any of
+// them more than once. Signing twice with the same nonce leaks the secret
+// key share. Nonces are kept in the [FROST] signer's nonce store, the signer
+// tracks just their commitments.
+type Signer struct {
+	signer *frost.Signer
+	nonces *journalNonceStore
+
+	ciphersuite frost.Ciphersuite
+	signerIndex uint64
+	publicKey   *frost.Point
+
+	committed map[string]bool // commitments of unspent nonces
+	restored  map[string]bool // commitments of unspent nonces from the journal
+	spent     map[string]bool // commitments of spent nonces
+}
+
+// NewSigner creates a new [ROAST] Signer instance. The threshold and the
+// group size are the minimum and the maximum number of signers taking part in
+// a [FROST] signing session.
+func NewSigner(
+	ciphersuite frost.Ciphersuite,
+	signerIndex uint64,
+	publicKey *frost.Point,
+	threshold int,
+	groupSize int,
+	secretKeyShare *big.Int,
+) *Signer {
+	nonces := &journalNonceStore{
+		curve:       ciphersuite.Curve(),
+		signerIndex: signerIndex,
+		nonces:      frost.NewMemoryNonceStore(),
+	}
+
+	return &Signer{
+		signer: frost.NewSignerWithNonceStore(
+			ciphersuite,
+			signerIndex,
+			publicKey,
+			threshold,
+			groupSize,
+			secretKeyShare,
+			nonces,
+		),
+		nonces:      nonces,
+		ciphersuite: ciphersuite,
+		signerIndex: signerIndex,
+		publicKey:   publicKey,
+		committed:   make(map[string]bool),
+		restored:    make(map[string]bool),
+		spent:       make(map[string]bool),
+	}
+}
+
+// NewSignerWithJournal creates a new [ROAST] Signer instance that appends
+// every nonce generated and every nonce spent to the journal. If the journal
+// is not empty, unspent nonces are restored from the journal before the
+// function returns. A nonce recorded as spent is never restored, no matter
+// the order of entries in the journal.
+//
+// Note that the journal holds secret nonces and must be protected the same
+// way as the secret key share.
+func NewSignerWithJournal(
+	ciphersuite frost.Ciphersuite,
+	signerIndex uint64,
+	publicKey *frost.Point,
+	threshold int,
+	groupSize int,
+	secretKeyShare *big.Int,
+	journal Journal,
+) (*Signer, error) {
+	s := NewSigner(
+		ciphersuite,
+		signerIndex,
+		publicKey,
+		threshold,
+		groupSize,
+		secretKeyShare,
+	)
+
+	entries, err := journal.Entries()
+	if err != nil {
+		return nil, fmt.Errorf("could not read journal: [%v]", err)
+	}
+
+	for _, entry := range entries {
+		if entry.Kind == JournalNonceSpent && entry.SignerIndex == signerIndex {
+			s.spent[entryKey(entry)] = true
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.Kind != JournalNonce || entry.SignerIndex != signerIndex {
+			continue
+		}
+
+		key := entryKey(entry)
+		if s.spent[key] || s.restored[key] {
+			continue
+		}
+
+		if entry.Commitment == nil {
+			return nil, fmt.Errorf("could not restore nonce: [commitment is missing]")
+		}
+
+		// The nonce is restored directly into the memory store, it is
+		// already in the journal.
+		curve := ciphersuite.Curve()
+		hidingNonce, err := frost.DeserializeScalar(curve, entry.HidingNonce)
+		if err != nil {
+			return nil, fmt.Errorf("could not restore hiding nonce: [%v]", err)
+		}
+		bindingNonce, err := frost.DeserializeScalar(curve, entry.BindingNonce)
+		if err != nil {
+			return nil, fmt.Errorf("could not restore binding nonce: [%v]", err)
+		}
+
+		err = s.nonces.nonces.Put(
+			entry.Commitment.commitment(curve),
+			frost.NewNonce(hidingNonce, bindingNonce),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("could not restore nonce: [%v]", err)
+		}
+		s.restored[key] = true
+	}
+
+	s.nonces.journal = journal
+
+	return s, nil
+}
+
+// Commit generates a fresh nonce and returns the initial response carrying
+// just the nonce commitment. The initial response should be sent to the
+// coordinator once, when the signing starts.
+func (s *Signer) Commit() (*Response, error) {
+	commitment, err := s.nextCommitment()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &Response{
+		SignerIndex:    s.signerIndex,
+		NextCommitment: commitment,
+	}
+	if err := s.Authenticate(response, nil); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Sign produces the signature share for the session using the nonce that
+// corresponds to this signer's commitment on the session's commitment list.
+// The nonce is marked as spent before the share is returned. Along with the
+// share, the function returns a fresh nonce commitment for the next session.
+//
+// The function returns an error if the session does not include this
+// signer's commitment, if the commitment is not known to this signer, or if
+// the nonce for the commitment has already been spent.
+func (s *Signer) Sign(session *Session) (*Response, error) {
+	return s.SignContext(context.Background(), session)
+}
+
+// SignContext is like Sign but stops with the context error if the context
+// is done before the signature share is produced. The nonce is not spent in
+// such a case.
+func (s *Signer) SignContext(
+	ctx context.Context,
+	session *Session,
+) (*Response, error) {
+	if session == nil {
+		return nil, fmt.Errorf("session is nil")
+	}
+
+	own, err := s.nonce(session)
+	if err != nil {
+		return nil, err
+	}
+
+	share, err := s.signShare(ctx, session, own)
+	if err != nil {
+		return nil, err
+	}
+
+	commitment, err := s.nextCommitment()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &Response{
+		SignerIndex:    s.signerIndex,
+		SessionID:      session.ID,
+		SignatureShare: share,
+		NextCommitment: commitment,
+	}
+	if err := s.Authenticate(response, session); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Authenticate sets the authentication of the response to the session. The
+// session must be nil for the initial response. Responses returned from
+// Commit and Sign are already authenticated; the function is meant for
+// responses built or modified by the caller. The function returns an error
+// if the signature share is set but is not a valid scalar.
+func (s *Signer) Authenticate(response *Response, session *Session) error {
+	var batch *BatchSession
+	if session != nil {
+		batch = session.batch()
+	}
+
+	authentications, err := s.authenticate(response.batch(), batch)
+	if err != nil {
+		return err
+	}
+
+	response.Authentication = authentications[0]
+	return nil
+}
+
+// authenticate returns the authentication of the batch response to the
+// session for every message. The number of messages is the number of nonce
+// commitments of the response or, if greater, the number of messages of the
+// session. The session is nil for the initial response.
+func (s *Signer) authenticate(
+	response *BatchResponse,
+	session *BatchSession,
+) ([]*frost.Signature, error) {
+	curve := s.ciphersuite.Curve()
+
+	for j, share := range response.SignatureShares {
+		if share != nil && !isScalar(curve, share) {
+			return nil, fmt.Errorf(
+				"signature share for message [%d] is not a valid scalar",
+				j,
+			)
+		}
+	}
+
+	count := len(response.NextCommitments)
+	if session != nil {
+		count = max(count, len(session.Messages))
+	}
+
+	authentications := make([]*frost.Signature, count)
+	for j := range authentications {
+		authentication, err := s.signer.Authenticate(
+			responseData(curve, response, j, session),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("could not authenticate response: [%v]", err)
+		}
+		authentications[j] = authentication
+	}
+
+	return authentications, nil
+}
+
+// nonce looks up this signer's commitment on the session's commitment list
+// and checks the nonce for the commitment is not spent yet.
+func (s *Signer) nonce(session *Session) (*frost.NonceCommitment, error) {
+	var own *frost.NonceCommitment
+	for _, c := range session.Commitments {
+		if c != nil && c.SignerIndex() == s.signerIndex {
+			own = c
+			break
+		}
+	}
+	if own == nil {
+		return nil, fmt.Errorf(
+			"session [%d] does not include commitment of signer [%d]",
+			session.ID,
+			s.signerIndex,
+		)
+	}
+
+	key := s.commitmentKey(own)
+	if s.spent[key] {
+		return nil, fmt.Errorf(
+			"nonce for the commitment in session [%d] has already been spent",
+			session.ID,
+		)
+	}
+	if !s.committed[key] && !s.restored[key] {
+		return nil, fmt.Errorf(
+			"nonce for the commitment in session [%d] is unknown",
+			session.ID,
+		)
+	}
+
+	return own, nil
+}
+
+// validate ensures [FROST] Round Two accepts the session's commitment list
+// and this signer's commitment without taking the nonce.
+func (s *Signer) validate(session *Session, own *frost.NonceCommitment) error {
+	if err := s.signer.ValidateRound2(own, session.Commitments); err != nil {
+		return fmt.Errorf(
+			"could not sign in session [%d]: [%v]",
+			session.ID,
+			err,
+		)
+	}
+	return nil
+}
+
+// signShare produces the signature share for the session with the nonce for
+// this signer's commitment and marks the nonce as spent. The nonce is taken
+// from the nonce store, and the spent nonce is journaled, by [FROST] Round
+// Two before the share is returned.
+func (s *Signer) signShare(
+	ctx context.Context,
+	session *Session,
+	own *frost.NonceCommitment,
+) (*big.Int, error) {
+	share, err := s.signer.Round2Context(
+		ctx,
+		session.Message,
+		own,
+		session.Commitments,
+	)
+	if err != nil && err == ctx.Err() {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not sign in session [%d]: [%v]",
+			session.ID,
+			err,
+		)
+	}
+
+	// The nonce must never be used again once the share was produced.
+	key := s.commitmentKey(own)
+	delete(s.committed, key)
+	delete(s.restored, key)
+	s.spent[key] = true
+
+	return share, nil
+}
+
+// nextCommitment executes [FROST] Round One. The generated nonce is kept in
+// the nonce store until it is used in a session.
+func (s *Signer) nextCommitment() (*frost.NonceCommitment, error) {
+	commitment, err := s.signer.Round1()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate nonce: [%v]", err)
+	}
+
+	s.committed[s.commitmentKey(commitment)] = true
+
+	return commitment, nil
+}
+
+// journalNonceStore is the frost.NonceStore of the signer. Nonces are kept in
+// memory and, if the signer has a journal, every nonce stored and every nonce
+// taken is appended to the journal first. This way, a nonce is journaled
+// before its commitment is published and is journaled as spent before the
+// signature share produced with it is published.
+type journalNonceStore struct {
+	curve       frost.Curve
+	signerIndex uint64
+
+	nonces  *frost.MemoryNonceStore
+	journal Journal // nil if the signer has no journal
+}
+
+// Put appends the nonce to the journal and stores it in memory.
+func (jns *journalNonceStore) Put(
+	commitment *frost.NonceCommitment,
+	nonce *frost.Nonce,
+) error {
+	if jns.journal != nil {
+		entry := &JournalEntry{
+			Kind:         JournalNonce,
+			SignerIndex:  jns.signerIndex,
+			Commitment:   newJournalCommitment(jns.curve, commitment),
+			HidingNonce:  frost.SerializeScalar(jns.curve, nonce.HidingNonce()),
+			BindingNonce: frost.SerializeScalar(jns.curve, nonce.BindingNonce()),
+		}
+		if err := jns.journal.Append(entry); err != nil {
+			return fmt.Errorf("could not append to journal: [%v]", err)
+		}
+	}
+
+	return jns.nonces.Put(commitment, nonce)
+}
+
+// Take appends the spent nonce to the journal and takes it from memory.
+func (jns *journalNonceStore) Take(
+	commitment *frost.NonceCommitment,
+) (*frost.Nonce, error) {
+	if jns.journal != nil {
+		entry := &JournalEntry{
+			Kind:        JournalNonceSpent,
+			SignerIndex: jns.signerIndex,
+			Commitment:  newJournalCommitment(jns.curve, commitment),
+		}
+		if err := jns.journal.Append(entry); err != nil {
+			return nil, fmt.Errorf("could not append to journal: [%v]", err)
+		}
+	}
+
+	return jns.nonces.Take(commitment)
+}
+
+// commitmentKey returns a unique key of the nonce commitment used to look up
+// the nonce.
+func (s *Signer) commitmentKey(commitment *frost.NonceCommitment) string {
+	curve := s.ciphersuite.Curve()
+	hiding := commitment.HidingNonceCommitment()
+	binding := commitment.BindingNonceCommitment()
+	if hiding == nil || binding == nil {
+		return ""
+	}
+	return string(curve.SerializePoint(hiding)) +
+		string(curve.SerializePoint(binding))
+}
+
+// entryKey returns the key of the nonce commitment stored in the journal
+// entry. The key is the same as the one returned from commitmentKey for the
+// commitment.
+func entryKey(entry *JournalEntry) string {
+	if entry.Commitment == nil {
+		return ""
+	}
+	return string(entry.Commitment.HidingNonceCommitment) +
+		string(entry.Commitment.BindingNonceCommitment)
+}
+
+// Run executes the signer over the transport for the given coordinators.
+// The signer sends the initial commitment to every coordinator and then
+// responds to every session received from any of them. Sessions the signer
+// refuses to sign in are ignored.
+//
+// In the semi-interactive mode of [ROAST], the signer serves several
+// coordinators at once. Each coordinator is served with a separate nonce
+// state so that a nonce committed to one coordinator is never used in
+// a session started by another one. The only exception are nonces restored
+// from the journal: it is not known which coordinator they were committed to,
+// so they can be used in a session of any coordinator, but only once.
+//
+// The signer sends the initial commitment also when restored from the
+// journal. The coordinator replaces the commitment received before the
+// restart with it or, if the signer was asked to sign in a session, sends the
+// session again.
+//
+// The function returns when any of the coordinators sends a valid signature
+// for the message of a session this signer received or when the transport is
+// closed. Signatures for other messages are ignored so that a coordinator
+// cannot stop the signer by replaying an old signature. If the context is
+// done first, the function returns the context error.
+func (s *Signer) Run(
+	ctx context.Context,
+	transport Transport,
+	coordinators ...Address,
+) error {
+	if len(coordinators) == 0 {
+		return fmt.Errorf("no coordinators")
+	}
+
+	states := make(map[Address]*Signer, len(coordinators))
+	for i, coordinator := range coordinators {
+		if i == 0 {
+			states[coordinator] = s
+		} else {
+			states[coordinator] = s.fork()
+		}
+
+		response, err := states[coordinator].Commit()
+		if err != nil {
+			return err
+		}
+
+		if err := transport.Send(coordinator, response); err != nil {
+			return fmt.Errorf(
+				"could not send the initial commitment to [%s]: [%v]",
+				coordinator,
+				err,
+			)
+		}
+	}
+
+	messages := make(map[string]bool)
+
+	for {
+		var envelope *Envelope
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e, ok := <-transport.Receive():
+			if !ok {
+				return nil
+			}
+			envelope = e
+		}
+
+		state, ok := states[envelope.Sender]
+		if !ok {
+			continue
+		}
+
+		switch message := envelope.Message.(type) {
+		case *Session:
+			if message == nil {
+				continue
+			}
+			messages[string(message.Message)] = true
+
+			response, err := state.SignContext(ctx, message)
+			if err != nil {
+				continue
+			}
+
+			// The delivery is not guaranteed anyway. If the response could
+			// not be sent, the coordinator sees this signer as not responsive.
+			_ = transport.Send(envelope.Sender, response)
+		case *Result:
+			if message == nil || !messages[string(message.Message)] {
+				continue
+			}
+			if s.isSignatureValid(message) {
+				return nil
+			}
+		}
+	}
+}
+
+// fork returns a new Signer instance with the same key material and a fresh
+// set of unspent nonce commitments. The nonce store, the sets of restored and
+// spent nonces, and the journal are shared with the original instance so that
+// no nonce can ever be spent twice.
+func (s *Signer) fork() *Signer {
+	return &Signer{
+		signer:      s.signer,
+		nonces:      s.nonces,
+		ciphersuite: s.ciphersuite,
+		signerIndex: s.signerIndex,
+		publicKey:   s.publicKey,
+		committed:   make(map[string]bool),
+		restored:    s.restored,
+		spent:       s.spent,
+	}
+}
+
+// isSignatureValid returns true if the result holds a valid signature under
+// the group public key.
+func (s *Signer) isSignatureValid(result *Result) bool {
+	if result.Signature == nil {
+		return false
+	}
+
+	valid, _ := s.ciphersuite.VerifySignature(
+		result.Signature,
+		s.publicKey,
+		result.Message,
+	)
+
+	return valid
+}
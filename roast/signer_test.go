@@ -0,0 +1,154 @@
+package roast
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"threshold.network/roast/frost"
+	"threshold.network/roast/internal/testutils"
+)
+
+func TestSigner_Roundtrip(t *testing.T) {
+	g := generateGroup(t)
+	coordinator := g.newCoordinator()
+	signers := g.newSigners()
+
+	var queue []*Response
+	for _, signer := range signers {
+		response, err := signer.Commit()
+		if err != nil {
+			t.Fatal(err)
+		}
+		queue = append(queue, response)
+	}
+
+	for len(queue) > 0 && coordinator.Signature() == nil {
+		response := queue[0]
+		queue = queue[1:]
+
+		session, err := coordinator.Receive(response)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if session == nil {
+			continue
+		}
+
+		for _, signerIndex := range session.Signers() {
+			response, err := signers[signerIndex-1].Sign(session)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.NextCommitment == nil {
+				t.Fatal("expected next commitment in the response")
+			}
+			queue = append(queue, response)
+		}
+	}
+
+	signature := coordinator.Signature()
+	if signature == nil {
+		t.Fatal("expected non-nil signature")
+	}
+
+	valid, err := ciphersuite.VerifySignature(signature, g.publicKey, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBoolsEqual(t, "signature verification result", true, valid)
+}
+
+func TestSigner_Sign_Failures(t *testing.T) {
+	g := generateGroup(t)
+
+	newSession := func(signers []*Signer) *Session {
+		commitments := make([]*frost.NonceCommitment, threshold)
+		for i := range commitments {
+			response, err := signers[i].Commit()
+			if err != nil {
+				t.Fatal(err)
+			}
+			commitments[i] = response.NextCommitment
+		}
+		return &Session{ID: 1, Message: message, Commitments: commitments}
+	}
+
+	tests := map[string]struct {
+		sign        func(signers []*Signer) error
+		expectedErr string
+	}{
+		"nil session": {
+			sign: func(signers []*Signer) error {
+				_, err := signers[0].Sign(nil)
+				return err
+			},
+			expectedErr: "session is nil",
+		},
+		"commitment not included in the session": {
+			sign: func(signers []*Signer) error {
+				_, err := signers[threshold].Sign(newSession(signers))
+				return err
+			},
+			expectedErr: "session [1] does not include commitment of signer [7]",
+		},
+		"nonce already spent": {
+			sign: func(signers []*Signer) error {
+				session := newSession(signers)
+				if _, err := signers[0].Sign(session); err != nil {
+					t.Fatal(err)
+				}
+				_, err := signers[0].Sign(session)
+				return err
+			},
+			expectedErr: "nonce for the commitment in session [1] has already been spent",
+		},
+		"unknown commitment": {
+			sign: func(signers []*Signer) error {
+				session := newSession(signers)
+				other := g.newSigners()
+				response, err := other[0].Commit()
+				if err != nil {
+					t.Fatal(err)
+				}
+				session.Commitments[0] = response.NextCommitment
+				_, err = signers[0].Sign(session)
+				return err
+			},
+			expectedErr: "nonce for the commitment in session [1] is unknown",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := test.sign(g.newSigners())
+			if err == nil {
+				t.Fatal("expected non-nil error")
+			}
+			testutils.AssertStringsEqual(t, "sign error", test.expectedErr, err.Error())
+		})
+	}
+}
+
+func TestSigner_Run_Cancelled(t *testing.T) {
+	g := generateGroup(t)
+
+	network := NewNetwork(LinkConfig{})
+	defer network.Close()
+
+	transport, err := network.Connect("signer-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := network.Connect("coordinator"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = g.newSigners()[0].Run(ctx, transport, "coordinator")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+}
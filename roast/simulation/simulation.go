@@ -0,0 +1,276 @@
+// Package simulation runs [ROAST] signing in-process against Byzantine
+// signers following pluggable malicious strategies. The simulation reports
+// the number of sessions started, the wall time, and the signers identified
+// as malicious so that the performance of [ROAST] can be tracked over time.
+//
+// [ROAST]
+//
+//	Ruffing T., Ronge V., Jin E., Schneider-Bensch J., Schroder D.,
+//	"ROAST: Robust Asynchronous Schnorr Threshold Signatures"
+//	<https://eprint.iacr.org/2022/550.pdf>
+//
+// [BIP-340]
+//
+//	Wuille, P., Nick, J., and Ruffing, T, "Schnorr Signatures for secp256k1",
+//	19 January 2020,
+//	<https://github.com/bitcoin/bips/blob/master/bip-0340.mediawiki>.
+package simulation
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	mrand "math/rand"
+	"slices"
+	"sync"
+	"time"
+
+	"threshold.network/roast/frost"
+	"threshold.network/roast/roast"
+)
+
+// Config is the configuration of a single simulation run.
+type Config struct {
+	GroupSize int
+	Threshold int
+
+	// Malicious is the number of malicious signers. Malicious signers are
+	// selected randomly from the group.
+	Malicious int
+	// Strategy is the strategy followed by all malicious signers.
+	Strategy Strategy
+
+	// Message is the message to sign.
+	Message []byte
+	// Link is the configuration of all links of the simulated network.
+	Link roast.LinkConfig
+}
+
+// Report is the result of a single simulation run.
+type Report struct {
+	Strategy        string
+	SessionsStarted int
+	WallTime        time.Duration
+
+	// Malicious are the identifiers of signers selected to be malicious.
+	Malicious []uint64
+	// Identified are the identifiers of signers the coordinator marked as
+	// malicious.
+	Identified []uint64
+
+	PublicKey *frost.Point
+	Signature *frost.Signature
+}
+
+// String returns a human-readable summary of the report.
+func (r *Report) String() string {
+	return fmt.Sprintf(
+		"strategy [%s]: [%d] sessions started, wall time [%v], "+
+			"[%d] of [%d] malicious signers identified",
+		r.Strategy,
+		r.SessionsStarted,
+		r.WallTime,
+		len(r.Identified),
+		len(r.Malicious),
+	)
+}
+
+// Run executes [ROAST] signing for a freshly generated group with the
+// configured number of malicious signers. The function returns the report
+// once the coordinator produced a signature or failed. Key generation is not
+// included in the reported wall time. If the context is done before the
+// signature was produced, the function returns the report of the signing so
+// far along with the error.
+func Run(ctx context.Context, config *Config) (*Report, error) {
+	if config.Threshold < 2 || config.Threshold > config.GroupSize {
+		return nil, fmt.Errorf(
+			"invalid threshold [%d] for group size [%d]",
+			config.Threshold,
+			config.GroupSize,
+		)
+	}
+	if config.Malicious < 0 || config.Malicious > config.GroupSize {
+		return nil, fmt.Errorf(
+			"invalid number of malicious signers [%d] for group size [%d]",
+			config.Malicious,
+			config.GroupSize,
+		)
+	}
+	if config.Malicious > 0 && config.Strategy == nil {
+		return nil, fmt.Errorf("strategy of malicious signers not set")
+	}
+
+	ciphersuite := frost.NewBip340Ciphersuite()
+
+	publicKey, secretKeyShares, verificationShares, err := generateGroup(
+		ciphersuite,
+		config.GroupSize,
+		config.Threshold,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	malicious := make(map[uint64]bool, config.Malicious)
+	for _, i := range mrand.Perm(config.GroupSize)[:config.Malicious] {
+		malicious[uint64(i+1)] = true
+	}
+
+	network := roast.NewNetwork(config.Link)
+	defer network.Close()
+
+	coordinatorAddress := roast.Address("coordinator")
+	coordinatorTransport, err := network.Connect(coordinatorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make(map[uint64]roast.Address, config.GroupSize)
+	transports := make(map[uint64]roast.Transport, config.GroupSize)
+	for i := uint64(1); i <= uint64(config.GroupSize); i++ {
+		addresses[i] = roast.Address(fmt.Sprintf("signer-%d", i))
+		transports[i], err = network.Connect(addresses[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	coordinator := roast.NewCoordinator(
+		ciphersuite,
+		publicKey,
+		config.Threshold,
+		config.GroupSize,
+		verificationShares,
+		config.Message,
+	)
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := uint64(1); i <= uint64(config.GroupSize); i++ {
+		signer := roast.NewSigner(
+			ciphersuite,
+			i,
+			publicKey,
+			config.Threshold,
+			config.GroupSize,
+			secretKeyShares[i],
+		)
+
+		wg.Add(1)
+		go func(i uint64) {
+			defer wg.Done()
+			if malicious[i] {
+				runMalicious(
+					signer,
+					config.Strategy,
+					transports[i],
+					coordinatorAddress,
+				)
+			} else {
+				_ = signer.Run(ctx, transports[i], coordinatorAddress)
+			}
+		}(i)
+	}
+
+	signature, runErr := coordinator.Run(ctx, coordinatorTransport, addresses)
+
+	wallTime := time.Since(start)
+
+	network.Close()
+	if s, ok := config.Strategy.(interface{ stop() }); ok {
+		s.stop()
+	}
+	wg.Wait()
+
+	report := &Report{
+		SessionsStarted: coordinator.SessionsStarted(),
+		WallTime:        wallTime,
+		Identified:      coordinator.Malicious(),
+		PublicKey:       publicKey,
+		Signature:       signature,
+	}
+	if config.Strategy != nil {
+		report.Strategy = config.Strategy.Name()
+	}
+	for i := range malicious {
+		report.Malicious = append(report.Malicious, i)
+	}
+	slices.Sort(report.Malicious)
+
+	if runErr != nil {
+		return report, fmt.Errorf("signing failed: [%w]", runErr)
+	}
+
+	return report, nil
+}
+
+// runMalicious executes the malicious signer over the transport following
+// the given strategy, until the transport is closed.
+func runMalicious(
+	signer *roast.Signer,
+	strategy Strategy,
+	transport roast.Transport,
+	coordinator roast.Address,
+) {
+	// The strategy may hold back the initial commitment so it must not block
+	// responding to sessions.
+	go func() {
+		for _, response := range strategy.Commit(signer) {
+			_ = transport.Send(coordinator, response)
+		}
+	}()
+
+	for envelope := range transport.Receive() {
+		session, ok := envelope.Message.(*roast.Session)
+		if !ok || envelope.Sender != coordinator {
+			continue
+		}
+
+		for _, response := range strategy.Respond(signer, session) {
+			_ = transport.Send(coordinator, response)
+		}
+	}
+}
+
+// generateGroup generates the group public key, secret key shares, and
+// verification shares, indexed by the signer identifier, using a trusted
+// dealer.
+func generateGroup(
+	ciphersuite frost.Ciphersuite,
+	groupSize int,
+	threshold int,
+) (
+	*frost.Point,
+	map[uint64]*big.Int,
+	map[uint64]*frost.Point,
+	error,
+) {
+	curve := ciphersuite.Curve()
+	order := curve.Order()
+
+	secretKey, err := rand.Int(rand.Reader, order)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not generate secret key: [%v]", err)
+	}
+
+	// The public key may have an odd Y coordinate. Signers negate their
+	// secret key shares in such a case, as required by [BIP-340].
+	output, err := frost.TrustedDealerKeygen(
+		ciphersuite,
+		secretKey,
+		threshold,
+		groupSize,
+	)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not split secret key: [%v]", err)
+	}
+
+	secretKeyShares := make(map[uint64]*big.Int, groupSize)
+	for _, keyShare := range output.KeyShares {
+		secretKeyShares[keyShare.SignerIndex] = keyShare.SecretKeyShare
+	}
+
+	return output.PublicKey, secretKeyShares, output.VerificationShares, nil
+}
@@ -0,0 +1,180 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"threshold.network/roast/frost"
+	"threshold.network/roast/internal/testutils"
+	"threshold.network/roast/roast"
+)
+
+var message = []byte("The world is indeed full of peril")
+
+func TestRun(t *testing.T) {
+	tests := map[string]struct {
+		strategy Strategy
+		// identified is true if the coordinator is expected to identify all
+		// malicious signers
+		identified bool
+	}{
+		"silent": {
+			strategy:   &Silent{},
+			identified: false,
+		},
+		"invalid shares": {
+			strategy:   &InvalidShares{},
+			identified: true,
+		},
+		"off-curve commitments": {
+			strategy:   &OffCurveCommitments{},
+			identified: true,
+		},
+		"commit then silent": {
+			strategy:   &CommitThenSilent{},
+			identified: false,
+		},
+		"coordinated": {
+			strategy:   &Coordinated{},
+			identified: false,
+		},
+		"equivocate": {
+			// Equivocating signers are identified only if they were asked
+			// to sign before the signature was produced.
+			strategy:   &Equivocate{},
+			identified: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			report, err := Run(context.Background(), &Config{
+				GroupSize: 10,
+				Threshold: 6,
+				Malicious: 4,
+				Strategy:  test.strategy,
+				Message:   message,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			valid, err := frost.NewBip340Ciphersuite().VerifySignature(
+				report.Signature,
+				report.PublicKey,
+				message,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutils.AssertBoolsEqual(t, "signature verification result", true, valid)
+
+			for _, i := range report.Identified {
+				if !slices.Contains(report.Malicious, i) {
+					t.Errorf("honest signer [%d] identified as malicious", i)
+				}
+			}
+
+			if test.identified && !slices.Equal(report.Malicious, report.Identified) {
+				t.Errorf(
+					"unexpected identified signers\nexpected: %v\nactual:   %v",
+					report.Malicious,
+					report.Identified,
+				)
+			}
+
+			if report.SessionsStarted == 0 {
+				t.Error("expected at least one session started")
+			}
+		})
+	}
+}
+
+func TestRun_TooManyMalicious(t *testing.T) {
+	report, err := Run(context.Background(), &Config{
+		GroupSize: 10,
+		Threshold: 6,
+		Malicious: 5,
+		Strategy:  &InvalidShares{},
+		Message:   message,
+	})
+	if err == nil {
+		t.Fatal("expected non-nil error")
+	}
+
+	testutils.AssertIntsEqual(t, "number of identified signers", 5, len(report.Identified))
+}
+
+func TestRun_Deadline(t *testing.T) {
+	// The coordinated strategy makes the coordinator start as many sessions
+	// as possible, so the signing does not complete before the deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	report, err := Run(ctx, &Config{
+		GroupSize: 100,
+		Threshold: 51,
+		Malicious: 49,
+		Strategy:  &Coordinated{},
+		Message:   message,
+		Link:      roast.LinkConfig{Latency: 10 * time.Millisecond},
+	})
+	if err == nil {
+		t.Fatal("expected non-nil error")
+	}
+
+	var interrupted *roast.InterruptedError
+	if !errors.As(err, &interrupted) {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded error; has [%v]", err)
+	}
+
+	if report.Signature != nil {
+		t.Error("expected nil signature")
+	}
+	testutils.AssertIntsEqual(
+		t,
+		"sessions started",
+		interrupted.Partial.SessionsStarted,
+		report.SessionsStarted,
+	)
+}
+
+// BenchmarkRun_51of100 runs the worst case scenario for a 51-of-100 group
+// where 49 malicious signers coordinate to cause the maximum DoS.
+func BenchmarkRun_51of100(b *testing.B) {
+	benchmarkRun(b, 100, 51, 49)
+}
+
+// BenchmarkRun_501of1000 reproduces the worst case scenario from the README:
+// a 501-of-1000 group where 499 malicious signers coordinate to cause the
+// maximum DoS. A single iteration takes tens of minutes, so the benchmark
+// should be run explicitly, for example:
+//
+//	go test ./roast/simulation -run=^$ -bench=501of1000 -benchtime=1x -timeout=0
+func BenchmarkRun_501of1000(b *testing.B) {
+	benchmarkRun(b, 1000, 501, 499)
+}
+
+func benchmarkRun(b *testing.B, groupSize, threshold, malicious int) {
+	for i := 0; i < b.N; i++ {
+		report, err := Run(context.Background(), &Config{
+			GroupSize: groupSize,
+			Threshold: threshold,
+			Malicious: malicious,
+			Strategy:  &Coordinated{},
+			Message:   message,
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.ReportMetric(float64(report.SessionsStarted), "sessions/op")
+		b.Log(report)
+	}
+}
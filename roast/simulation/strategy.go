@@ -0,0 +1,264 @@
+package simulation
+
+import (
+	"math/big"
+	"sync"
+
+	"threshold.network/roast/frost"
+	"threshold.network/roast/roast"
+)
+
+// Strategy defines the behaviour of a malicious signer. The strategy is
+// given the honest signer instance so that it can produce valid protocol
+// messages whenever it needs to. All malicious signers of the simulation
+// share the same strategy, which lets them coordinate.
+type Strategy interface {
+	// Name returns a human-readable name of the strategy.
+	Name() string
+
+	// Commit is called once, when the signing starts. It returns responses
+	// the malicious signer sends to the coordinator instead of the initial
+	// commitment.
+	Commit(signer *roast.Signer) []*roast.Response
+
+	// Respond is called for every session the malicious signer was included
+	// in. It returns responses the malicious signer sends to the coordinator
+	// instead of the signature share.
+	Respond(signer *roast.Signer, session *roast.Session) []*roast.Response
+}
+
+// Silent is a strategy of a malicious signer that never responds.
+type Silent struct{}
+
+func (s *Silent) Name() string {
+	return "silent"
+}
+
+func (s *Silent) Commit(*roast.Signer) []*roast.Response {
+	return nil
+}
+
+func (s *Silent) Respond(*roast.Signer, *roast.Session) []*roast.Response {
+	return nil
+}
+
+// InvalidShares is a strategy of a malicious signer that sends valid
+// commitments but invalid signature shares.
+type InvalidShares struct{}
+
+func (is *InvalidShares) Name() string {
+	return "invalid shares"
+}
+
+func (is *InvalidShares) Commit(signer *roast.Signer) []*roast.Response {
+	return commit(signer)
+}
+
+func (is *InvalidShares) Respond(
+	signer *roast.Signer,
+	session *roast.Session,
+) []*roast.Response {
+	response, err := signer.Sign(session)
+	if err != nil {
+		return nil
+	}
+
+	response.SignatureShare = new(big.Int).Add(
+		response.SignatureShare,
+		big.NewInt(1),
+	)
+
+	return authenticate(signer, response, session)
+}
+
+// OffCurveCommitments is a strategy of a malicious signer that sends nonce
+// commitments that are not valid points on the curve.
+type OffCurveCommitments struct{}
+
+func (occ *OffCurveCommitments) Name() string {
+	return "off-curve commitments"
+}
+
+func (occ *OffCurveCommitments) Commit(signer *roast.Signer) []*roast.Response {
+	response, err := signer.Commit()
+	if err != nil {
+		return nil
+	}
+
+	response.NextCommitment = offCurveCommitment(response.SignerIndex)
+
+	return authenticate(signer, response, nil)
+}
+
+func (occ *OffCurveCommitments) Respond(
+	signer *roast.Signer,
+	session *roast.Session,
+) []*roast.Response {
+	response, err := signer.Sign(session)
+	if err != nil {
+		return nil
+	}
+
+	response.NextCommitment = offCurveCommitment(response.SignerIndex)
+
+	return authenticate(signer, response, session)
+}
+
+func offCurveCommitment(signerIndex uint64) *frost.NonceCommitment {
+	return frost.NewNonceCommitment(
+		signerIndex,
+		&frost.Point{X: big.NewInt(1), Y: big.NewInt(2)},
+		&frost.Point{X: big.NewInt(3), Y: big.NewInt(4)},
+	)
+}
+
+// CommitThenSilent is a strategy of a malicious signer that sends a valid
+// initial commitment and then never responds. Signers following this
+// strategy get included in sessions and stall them.
+type CommitThenSilent struct{}
+
+func (cts *CommitThenSilent) Name() string {
+	return "commit then silent"
+}
+
+func (cts *CommitThenSilent) Commit(signer *roast.Signer) []*roast.Response {
+	return commit(signer)
+}
+
+func (cts *CommitThenSilent) Respond(
+	*roast.Signer,
+	*roast.Session,
+) []*roast.Response {
+	return nil
+}
+
+// Coordinated is a strategy of malicious signers coordinating to cause the
+// maximum DoS. Malicious signers release their commitments one by one: the
+// next malicious signer commits only once the previous one got included in
+// a session. Every session is then likely to include exactly one malicious
+// signer who never responds, so the coordinator has to start as many
+// sessions as possible.
+//
+// The strategy holds the state of the coordinated signers and must not be
+// reused across simulation runs.
+type Coordinated struct {
+	mutex    sync.Mutex
+	started  bool
+	waiting  []chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func (c *Coordinated) Name() string {
+	return "coordinated"
+}
+
+func (c *Coordinated) Commit(signer *roast.Signer) []*roast.Response {
+	c.mutex.Lock()
+	if c.done == nil {
+		c.done = make(chan struct{})
+	}
+	if !c.started {
+		c.started = true
+		c.mutex.Unlock()
+		return commit(signer)
+	}
+	turn := make(chan struct{})
+	c.waiting = append(c.waiting, turn)
+	done := c.done
+	c.mutex.Unlock()
+
+	select {
+	case <-turn:
+		return commit(signer)
+	case <-done:
+		return nil
+	}
+}
+
+func (c *Coordinated) Respond(
+	*roast.Signer,
+	*roast.Session,
+) []*roast.Response {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if len(c.waiting) > 0 {
+		close(c.waiting[0])
+		c.waiting = c.waiting[1:]
+	}
+
+	return nil
+}
+
+// stop releases all malicious signers still waiting for their turn.
+func (c *Coordinated) stop() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.done == nil {
+		c.done = make(chan struct{})
+	}
+	c.stopOnce.Do(func() { close(c.done) })
+}
+
+// Equivocate is a strategy of a malicious signer that sends two different
+// responses every time it is asked to sign in a session. The initial
+// commitment is sent once: sending it again is how a restarted signer
+// replaces its commitment and it is not an offence.
+type Equivocate struct{}
+
+func (e *Equivocate) Name() string {
+	return "equivocate"
+}
+
+func (e *Equivocate) Commit(signer *roast.Signer) []*roast.Response {
+	return commit(signer)
+}
+
+func (e *Equivocate) Respond(
+	signer *roast.Signer,
+	session *roast.Session,
+) []*roast.Response {
+	response, err := signer.Sign(session)
+	if err != nil {
+		return nil
+	}
+
+	other, err := signer.Commit()
+	if err != nil {
+		return []*roast.Response{response}
+	}
+
+	return append(
+		[]*roast.Response{response},
+		authenticate(signer, &roast.Response{
+			SignerIndex:    response.SignerIndex,
+			SessionID:      response.SessionID,
+			SignatureShare: response.SignatureShare,
+			NextCommitment: other.NextCommitment,
+		}, session)...,
+	)
+}
+
+// authenticate authenticates the response modified by the strategy, the same
+// way the honest signer does, so that the coordinator processes it and can
+// attribute the offence to the signer.
+func authenticate(
+	signer *roast.Signer,
+	response *roast.Response,
+	session *roast.Session,
+) []*roast.Response {
+	if err := signer.Authenticate(response, session); err != nil {
+		return nil
+	}
+	return []*roast.Response{response}
+}
+
+func commit(signer *roast.Signer) []*roast.Response {
+	response, err := signer.Commit()
+	if err != nil {
+		return nil
+	}
+	return []*roast.Response{response}
+}
@@ -0,0 +1,74 @@
+package roast
+
+// Address identifies a party of the [ROAST] protocol connected to the
+// transport.
+type Address string
+
+// Message is a typed [ROAST] protocol message exchanged between the parties
+// over the transport.
+type Message interface {
+	// Type returns the unique type of the protocol message.
+	Type() string
+}
+
+// Envelope wraps the protocol message with the addresses of the sender and
+// the receiver.
+type Envelope struct {
+	Sender   Address
+	Receiver Address
+	Message  Message
+}
+
+// Transport is the interface of the network layer used by the [ROAST]
+// parties to exchange protocol messages. [ROAST] does not assume reliable or
+// ordered delivery. The messages can be delayed, reordered, or dropped, and
+// the protocol still has to make progress as long as enough honest signers
+// eventually respond.
+type Transport interface {
+	// Address returns the address of the party using the transport.
+	Address() Address
+
+	// Send sends the message to the receiver. The function does not wait for
+	// the message to be delivered and the delivery is not guaranteed. An error
+	// is returned if the message could not be sent at all, for example,
+	// because the transport is closed or the receiver is unknown.
+	Send(receiver Address, message Message) error
+
+	// Receive returns the channel with messages delivered to the party. The
+	// channel is closed when the transport is closed.
+	Receive() <-chan *Envelope
+
+	// Close disconnects the party from the network. No more messages are
+	// delivered after the transport is closed.
+	Close() error
+}
+
+// Type returns the type of the session message.
+func (s *Session) Type() string {
+	return "roast/session"
+}
+
+// Type returns the type of the response message.
+func (r *Response) Type() string {
+	return "roast/response"
+}
+
+// Type returns the type of the result message.
+func (r *Result) Type() string {
+	return "roast/result"
+}
+
+// Type returns the type of the batch session message.
+func (bs *BatchSession) Type() string {
+	return "roast/batch-session"
+}
+
+// Type returns the type of the batch response message.
+func (br *BatchResponse) Type() string {
+	return "roast/batch-response"
+}
+
+// Type returns the type of the batch result message.
+func (br *BatchResult) Type() string {
+	return "roast/batch-result"
+}